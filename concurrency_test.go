@@ -0,0 +1,112 @@
+/* Copyright 2020 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package negotiator_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/freerware/negotiator"
+	_representation "github.com/freerware/negotiator/internal/representation"
+	"github.com/freerware/negotiator/internal/test"
+	"github.com/freerware/negotiator/proactive"
+	"github.com/freerware/negotiator/reactive"
+	"github.com/freerware/negotiator/representation"
+	"github.com/freerware/negotiator/transparent"
+	"github.com/stretchr/testify/suite"
+)
+
+type ConcurrencyTestSuite struct {
+	suite.Suite
+}
+
+func TestConcurrencyTestSuite(t *testing.T) {
+	suite.Run(t, new(ConcurrencyTestSuite))
+}
+
+// headers are the request headers sent concurrently, including none at all
+// so that the shared default headers are exercised.
+var headers = []http.Header{
+	{},
+	{
+		"Accept":          {"text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8"},
+		"Accept-Language": {"en-US,en;q=0.5"},
+		"Accept-Charset":  {"utf-8;q=0.7, ascii"},
+		"Accept-Encoding": {"gzip, deflate, br, zstd"},
+	},
+	{
+		"Accept":          {"application/xml;q=0.5, application/json"},
+		"Accept-Language": {"fr;q=0.1, en-US"},
+		"Negotiate":       {"trans, vlist"},
+	},
+	{
+		"Accept":          {"*/*;q=0.1, application/*;q=0.9, application/json"},
+		"Accept-Language": {"*;q=0.5, en"},
+		"Accept-Charset":  {"*"},
+		"Negotiate":       {"1.0"},
+	},
+}
+
+func (s ConcurrencyTestSuite) representations(r *http.Request) []representation.Representation {
+	var reps []representation.Representation
+	for _, t := range []string{"application/json", "application/xml"} {
+		for _, l := range []string{"en-US", "fr"} {
+			reps = append(reps, _representation.NewBuilder().
+				WithLocation(*r.URL).
+				WithType(t).
+				WithLanguage(l).
+				WithCharset("ascii").
+				WithSourceQuality(1.0).
+				Build(test.RepresentationBuilderFunc))
+		}
+	}
+	return reps
+}
+
+func (s ConcurrencyTestSuite) TestConcurrentNegotiation() {
+	// arrange.
+	negotiators := map[string]negotiator.Negotiator{
+		"proactive":   proactive.New(),
+		"reactive":    reactive.New(),
+		"transparent": transparent.New(transparent.RVSA(transparent.RVSA1())),
+	}
+	const iterations = 50
+
+	// action.
+	var wg sync.WaitGroup
+	for name, n := range negotiators {
+		for _, h := range headers {
+			for i := 0; i < iterations; i++ {
+				wg.Add(1)
+				go func(name string, n negotiator.Negotiator, h http.Header) {
+					defer wg.Done()
+					r := httptest.NewRequest("GET", "http://freer.ddns.net/thing", nil)
+					r.Header = h.Clone()
+					w := httptest.NewRecorder()
+					ctx := negotiator.NegotiationContext{Request: r, ResponseWriter: w}
+
+					// assert.
+					err := n.Negotiate(ctx, s.representations(r)...)
+					s.NoError(err, name)
+					s.NotZero(w.Result().StatusCode, name)
+				}(name, n, h)
+			}
+		}
+	}
+	wg.Wait()
+}
@@ -0,0 +1,176 @@
+/* Copyright 2020 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package negotiator
+
+import (
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/freerware/negotiator/header"
+	"github.com/freerware/negotiator/representation"
+)
+
+// Outcome represents the result of content negotiation.
+type Outcome int
+
+// Defines the outcomes of content negotiation.
+const (
+	// OutcomeNoContent indicates there were no representations to negotiate.
+	OutcomeNoContent Outcome = iota
+	// OutcomeAcceptable indicates a representation acceptable to the user
+	// agent was chosen.
+	OutcomeAcceptable
+	// OutcomeNotAcceptable indicates none of the representations are
+	// acceptable to the user agent.
+	OutcomeNotAcceptable
+	// OutcomeMultipleChoices indicates the user agent is to choose amongst
+	// the available representations.
+	OutcomeMultipleChoices
+	// OutcomeList indicates a transparent negotiation 'list' response.
+	OutcomeList
+	// OutcomeChoice indicates a transparent negotiation 'choice' response.
+	OutcomeChoice
+	// OutcomeAdhoc indicates a transparent negotiation 'adhoc' response.
+	OutcomeAdhoc
+	// OutcomeVariantAlsoNegotiates indicates a variant of the resource is
+	// itself a negotiable resource.
+	OutcomeVariantAlsoNegotiates
+)
+
+// String provides the textual representation of the outcome.
+func (o Outcome) String() string {
+	switch o {
+	case OutcomeNoContent:
+		return "no content"
+	case OutcomeAcceptable:
+		return "acceptable"
+	case OutcomeNotAcceptable:
+		return "not acceptable"
+	case OutcomeMultipleChoices:
+		return "multiple choices"
+	case OutcomeList:
+		return "list"
+	case OutcomeChoice:
+		return "choice"
+	case OutcomeAdhoc:
+		return "adhoc"
+	case OutcomeVariantAlsoNegotiates:
+		return "variant also negotiates"
+	default:
+		return "unknown"
+	}
+}
+
+// Decision represents the decision made during content negotiation, which
+// captures everything needed to respond to the user agent.
+type Decision struct {
+	// Outcome is the result of content negotiation.
+	Outcome Outcome
+	// Representation is the representation chosen on behalf of the user
+	// agent, if any.
+	Representation representation.Representation
+	// List is the representation describing the available representations
+	// and their metadata, if any.
+	List representation.Representation
+	// StatusCode is the HTTP status code to respond with.
+	StatusCode int
+	// Header contains the HTTP headers to respond with.
+	Header http.Header
+}
+
+// Body provides the representation to serve as the response body, which is
+// the chosen representation when present and the list representation
+// otherwise.
+func (d Decision) Body() representation.Representation {
+	if d.Representation != nil {
+		return d.Representation
+	}
+	return d.List
+}
+
+// WriteTo writes the response body to the provided writer. When the writer
+// is an http.ResponseWriter, the headers and status code are written as well,
+// with the Vary header merged into any already present on the response.
+//
+// Bodies capable of being streamed via io.WriterTo are written without being
+// buffered, in which case the Content-Length header is only written when the
+// body reports its exact content length. Content length hints are estimates,
+// and therefore never written.
+func (d Decision) WriteTo(w io.Writer) (int64, error) {
+	body := d.Body()
+	if body == nil {
+		d.writeHeader(w, -1)
+		return 0, nil
+	}
+	if wt, ok := body.(io.WriterTo); ok {
+		length := -1
+		if r, ok := body.(representation.ContentLengthReporter); ok {
+			if l, known := r.ContentLength(); known {
+				length = l
+			}
+		}
+		d.writeHeader(w, length)
+		return wt.WriteTo(w)
+	}
+	b, err := body.Bytes()
+	if err != nil {
+		return 0, err
+	}
+	d.writeHeader(w, len(b))
+	n, err := w.Write(b)
+	return int64(n), err
+}
+
+// writeHeader writes the headers and status code when the provided writer
+// is an http.ResponseWriter. The Content-Length header is only written when
+// the provided content length is known.
+func (d Decision) writeHeader(w io.Writer, length int) {
+	rw, ok := w.(http.ResponseWriter)
+	if !ok {
+		return
+	}
+	h := rw.Header()
+	for key, values := range d.Header {
+		if key == "Vary" {
+			vary := header.NewVary(append(h.Values(key), values...))
+			if vary.IsEmpty() {
+				continue
+			}
+			values = []string{vary.ValuesAsString()}
+		}
+		if len(values) == 0 {
+			continue
+		}
+		h[key] = append([]string(nil), values...)
+	}
+	if length >= 0 {
+		h.Set("Content-Length", strconv.Itoa(length))
+	}
+	rw.WriteHeader(d.StatusCode)
+}
+
+// ContentHeaders provides the headers describing the provided representation,
+// which negotiators respond with alongside it.
+func ContentHeaders(rep representation.Representation) http.Header {
+	h := make(http.Header)
+	h.Set("Content-Type", rep.ContentType())
+	h.Set("Content-Encoding", strings.Join(rep.ContentEncoding(), ","))
+	h.Set("Content-Language", rep.ContentLanguage())
+	h.Set("Content-Charset", rep.ContentCharset())
+	return h
+}
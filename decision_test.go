@@ -0,0 +1,287 @@
+/* Copyright 2020 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package negotiator_test
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"testing/fstest"
+
+	"github.com/freerware/negotiator"
+	_representation "github.com/freerware/negotiator/internal/representation"
+	"github.com/freerware/negotiator/internal/test"
+	"github.com/freerware/negotiator/representation"
+	"github.com/stretchr/testify/suite"
+)
+
+type DecisionTestSuite struct {
+	suite.Suite
+}
+
+func TestDecisionTestSuite(t *testing.T) {
+	suite.Run(t, new(DecisionTestSuite))
+}
+
+func (s DecisionTestSuite) representation() representation.Representation {
+	return _representation.NewBuilder().
+		WithType("application/json").
+		WithLanguage("en-US").
+		WithCharset("ascii").
+		WithSourceQuality(1.0).
+		Build(test.RepresentationBuilderFunc)
+}
+
+func (s DecisionTestSuite) TestDecision_Body() {
+	rep, list := s.representation(), s.representation()
+	tests := []struct {
+		name     string
+		decision negotiator.Decision
+		out      representation.Representation
+	}{
+		{"Representation", negotiator.Decision{Representation: rep, List: list}, rep},
+		{"List", negotiator.Decision{List: list}, list},
+		{"None", negotiator.Decision{}, nil},
+	}
+
+	for _, test := range tests {
+		s.Run(test.name, func() {
+			// action + assert.
+			s.Equal(test.out, test.decision.Body())
+		})
+	}
+}
+
+func (s DecisionTestSuite) TestDecision_WriteTo_ResponseWriter() {
+	// arrange.
+	rep := s.representation()
+	expectedBytes, _ := rep.Bytes()
+	d := negotiator.Decision{
+		Outcome:        negotiator.OutcomeAcceptable,
+		Representation: rep,
+		StatusCode:     http.StatusOK,
+		Header: http.Header{
+			"Content-Type": []string{"application/json"},
+			"Vary":         []string{"Accept, Accept-Language"},
+		},
+	}
+	responseWriter := httptest.NewRecorder()
+	responseWriter.Header().Set("Vary", "Cookie, accept")
+
+	// action.
+	n, err := d.WriteTo(responseWriter)
+
+	// assert.
+	s.Require().NoError(err)
+	response := responseWriter.Result()
+	s.Equal(int64(len(expectedBytes)), n)
+	s.Equal(http.StatusOK, response.StatusCode)
+	s.Equal(expectedBytes, responseWriter.Body.Bytes())
+	s.Equal("application/json", response.Header.Get("Content-Type"))
+	s.Equal("Cookie, Accept, Accept-Language", response.Header.Get("Vary"))
+	s.Equal(strconv.Itoa(len(expectedBytes)), response.Header.Get("Content-Length"))
+}
+
+func (s DecisionTestSuite) TestDecision_WriteTo_Stream() {
+	// arrange.
+	reps, err := representation.Of(
+		struct {
+			ID int `json:"id"`
+		}{ID: 1},
+		representation.MediaTypes("application/json"),
+	)
+	s.Require().NoError(err)
+	d := negotiator.Decision{
+		Outcome:        negotiator.OutcomeAcceptable,
+		Representation: reps[0],
+		StatusCode:     http.StatusOK,
+		Header:         http.Header{"Content-Type": []string{"application/json"}},
+	}
+	responseWriter := httptest.NewRecorder()
+
+	// action.
+	n, err := d.WriteTo(responseWriter)
+
+	// assert.
+	s.Require().NoError(err)
+	response := responseWriter.Result()
+	s.Equal(int64(responseWriter.Body.Len()), n)
+	s.Equal(http.StatusOK, response.StatusCode)
+	s.Equal(`{"id":1}`+"\n", responseWriter.Body.String())
+	s.Empty(response.Header.Get("Content-Length"))
+}
+
+func (s DecisionTestSuite) TestDecision_WriteTo_StreamLength() {
+	// arrange.
+	hinted := representation.NewLazy(func() (string, error) { return "thing", nil })
+	hinted.SetContentType("application/json")
+	hinted.SetContentLength(5)
+	memoized := representation.NewLazy(func() (string, error) { return "thing", nil })
+	memoized.SetContentType("application/json")
+	_, err := memoized.Bytes()
+	s.Require().NoError(err)
+	file := representation.NewFile(fstest.MapFS{"thing.txt": {Data: []byte("thing")}}, "thing.txt")
+	file.SetContentType("text/plain")
+	file.SetContentLength(2)
+	tests := []struct {
+		name   string
+		body   representation.Representation
+		length string
+		n      int64
+	}{
+		{"Hint", hinted, "", int64(len(`"thing"`) + 1)},
+		{"MemoizedLazy", memoized, "7", int64(len(`"thing"`))},
+		{"File", file, "5", int64(len("thing"))},
+	}
+	for _, test := range tests {
+		s.Run(test.name, func() {
+			// arrange.
+			d := negotiator.Decision{
+				Outcome:        negotiator.OutcomeAcceptable,
+				Representation: test.body,
+				StatusCode:     http.StatusOK,
+				Header:         make(http.Header),
+			}
+			responseWriter := httptest.NewRecorder()
+
+			// action.
+			n, err := d.WriteTo(responseWriter)
+
+			// assert.
+			s.Require().NoError(err)
+			response := responseWriter.Result()
+			s.Equal(test.length, response.Header.Get("Content-Length"))
+			s.Equal(test.n, n)
+			s.Equal(test.n, int64(responseWriter.Body.Len()))
+		})
+	}
+}
+
+func (s DecisionTestSuite) TestDecision_WriteTo_WildcardVary() {
+	// arrange.
+	d := negotiator.Decision{
+		Outcome:    negotiator.OutcomeNotAcceptable,
+		StatusCode: http.StatusNotAcceptable,
+		Header:     http.Header{"Vary": []string{"Accept"}},
+	}
+	responseWriter := httptest.NewRecorder()
+	responseWriter.Header().Set("Vary", "*")
+
+	// action.
+	n, err := d.WriteTo(responseWriter)
+
+	// assert.
+	s.Require().NoError(err)
+	response := responseWriter.Result()
+	s.Zero(n)
+	s.Equal(http.StatusNotAcceptable, response.StatusCode)
+	s.Equal("*", response.Header.Get("Vary"))
+	s.Empty(response.Header.Get("Content-Length"))
+	s.Zero(responseWriter.Body.Len())
+}
+
+func (s DecisionTestSuite) TestDecision_WriteTo_Writer() {
+	// arrange.
+	rep := s.representation()
+	expectedBytes, _ := rep.Bytes()
+	d := negotiator.Decision{
+		Outcome:    negotiator.OutcomeMultipleChoices,
+		List:       rep,
+		StatusCode: http.StatusMultipleChoices,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+	}
+	var buf bytes.Buffer
+
+	// action.
+	n, err := d.WriteTo(&buf)
+
+	// assert.
+	s.Require().NoError(err)
+	s.Equal(int64(len(expectedBytes)), n)
+	s.Equal(expectedBytes, buf.Bytes())
+}
+
+func (s DecisionTestSuite) TestDecision_WriteTo_BytesError() {
+	// arrange.
+	errExpected := errors.New("whoa")
+	d := negotiator.Decision{Representation: errorRepresentation{err: errExpected}, StatusCode: http.StatusOK}
+	responseWriter := httptest.NewRecorder()
+
+	// action.
+	_, err := d.WriteTo(responseWriter)
+
+	// assert.
+	s.Require().ErrorIs(err, errExpected)
+	s.False(responseWriter.Flushed)
+	s.Zero(responseWriter.Body.Len())
+}
+
+func (s DecisionTestSuite) TestContentHeaders() {
+	// arrange.
+	rep := _representation.NewBuilder().
+		WithType("application/json").
+		WithLanguage("en-US").
+		WithCharset("ascii").
+		WithEncoding("gzip").
+		WithEncoding("br").
+		Build(test.RepresentationBuilderFunc)
+
+	// action.
+	h := negotiator.ContentHeaders(rep)
+
+	// assert.
+	s.Equal("application/json", h.Get("Content-Type"))
+	s.Equal("en-US", h.Get("Content-Language"))
+	s.Equal("ascii", h.Get("Content-Charset"))
+	s.Equal("gzip,br", h.Get("Content-Encoding"))
+}
+
+func (s DecisionTestSuite) TestOutcome_String() {
+	tests := []struct {
+		in  negotiator.Outcome
+		out string
+	}{
+		{negotiator.OutcomeNoContent, "no content"},
+		{negotiator.OutcomeAcceptable, "acceptable"},
+		{negotiator.OutcomeNotAcceptable, "not acceptable"},
+		{negotiator.OutcomeMultipleChoices, "multiple choices"},
+		{negotiator.OutcomeList, "list"},
+		{negotiator.OutcomeChoice, "choice"},
+		{negotiator.OutcomeAdhoc, "adhoc"},
+		{negotiator.OutcomeVariantAlsoNegotiates, "variant also negotiates"},
+		{negotiator.Outcome(-1), "unknown"},
+	}
+
+	for _, test := range tests {
+		s.Run(test.out, func() {
+			// action + assert.
+			s.Equal(test.out, test.in.String())
+		})
+	}
+}
+
+type errorRepresentation struct {
+	test.Representation
+
+	err error
+}
+
+func (r errorRepresentation) Bytes() ([]byte, error) {
+	return nil, r.err
+}
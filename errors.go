@@ -0,0 +1,48 @@
+/* Copyright 2020 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package negotiator
+
+import "github.com/freerware/negotiator/header"
+
+// Errors that can be returned from negotiators.
+var (
+	// ErrInvalidHeader represents an error encountered when a request header
+	// consulted during negotiation is malformed.
+	ErrInvalidHeader = header.ErrInvalidHeader
+)
+
+// HeaderError represents an error encountered when parsing a request header
+// consulted during negotiation. It describes the malformed element of the
+// header, which is attributable to the user agent.
+type HeaderError = header.Error
+
+// HeaderPolicy represents how negotiators treat malformed request headers.
+type HeaderPolicy = header.Policy
+
+// Policies for treating malformed request headers.
+const (
+	// RejectInvalidHeaders fails negotiation with a HeaderError, which
+	// handlers respond to with a 400 HTTP status code.
+	RejectInvalidHeaders = header.RejectInvalidHeaders
+
+	// IgnoreInvalidHeaders disregards malformed headers entirely, as if
+	// the user agent did not send them.
+	IgnoreInvalidHeaders = header.IgnoreInvalidHeaders
+
+	// IgnoreInvalidElements disregards only the malformed elements of
+	// headers, as RFC 9110 recommends for lenient parsing.
+	IgnoreInvalidElements = header.IgnoreInvalidElements
+)
@@ -0,0 +1,78 @@
+/* Copyright 2020 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package negotiator_test
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/freerware/negotiator"
+	"github.com/stretchr/testify/suite"
+)
+
+type ErrorsTestSuite struct {
+	suite.Suite
+}
+
+func TestErrorsTestSuite(t *testing.T) {
+	suite.Run(t, new(ErrorsTestSuite))
+}
+
+func (s ErrorsTestSuite) TestHeaderError() {
+	// arrange.
+	cause := strconv.ErrSyntax
+	var err error = &negotiator.HeaderError{
+		Header: "Accept",
+		Value:  "text/html;q=abc",
+		Offset: 0,
+		Cause:  cause,
+	}
+	err = fmt.Errorf("negotiating: %w", err)
+
+	// action.
+	var headerErr *negotiator.HeaderError
+	ok := errors.As(err, &headerErr)
+
+	// assert.
+	s.Require().True(ok)
+	s.Equal("Accept", headerErr.Header)
+	s.Equal("text/html;q=abc", headerErr.Value)
+	s.ErrorIs(err, negotiator.ErrInvalidHeader)
+	s.ErrorIs(err, cause)
+	s.Equal(
+		`invalid header Accept: "text/html;q=abc" at offset 0: invalid syntax`,
+		headerErr.Error(),
+	)
+}
+
+func (s ErrorsTestSuite) TestHeaderPolicy_String() {
+	tests := []struct {
+		in  negotiator.HeaderPolicy
+		out string
+	}{
+		{negotiator.RejectInvalidHeaders, "reject invalid headers"},
+		{negotiator.IgnoreInvalidHeaders, "ignore invalid headers"},
+		{negotiator.IgnoreInvalidElements, "ignore invalid elements"},
+		{negotiator.HeaderPolicy(42), "unknown"},
+	}
+	for _, test := range tests {
+		s.Run(test.out, func() {
+			s.Equal(test.out, test.in.String())
+		})
+	}
+}
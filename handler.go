@@ -0,0 +1,258 @@
+/* Copyright 2020 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package negotiator
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/freerware/negotiator/header"
+	"github.com/freerware/negotiator/representation"
+	"go.uber.org/zap"
+)
+
+// HandlerFunc provides the representations of the resource targeted by the
+// request, which are subject to content negotiation.
+type HandlerFunc func(*http.Request) ([]representation.Representation, error)
+
+// ErrorConstructor constructs the representations describing an error
+// encountered while handling a request, which are negotiated when responding
+// to the user agent with the provided HTTP status code.
+type ErrorConstructor func(status int, err error) []representation.Representation
+
+// errorRepresentations constructs problem details in JSON
+// (application/problem+json) and XML (application/problem+xml) describing the
+// provided error. Details are only disclosed for client errors.
+var errorRepresentations = func(status int, err error) []representation.Representation {
+	var reps []representation.Representation
+	for _, mediaType := range []string{representation.ProblemJSON, representation.ProblemXML} {
+		p := representation.NewProblem(mediaType, status)
+		if status < http.StatusInternalServerError {
+			p.Detail = err.Error()
+		}
+		reps = append(reps, p)
+	}
+	return reps
+}
+
+// DefaultErrorStatus maps the provided error to an HTTP status code, where
+// malformed request headers result in a 400 HTTP status code and all other
+// errors result in a 500 HTTP status code.
+func DefaultErrorStatus(err error) int {
+	if errors.Is(err, ErrInvalidHeader) {
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
+
+// handler represents an HTTP handler that performs content negotiation on
+// the representations provided by a handler function.
+type handler struct {
+	negotiator                     Negotiator
+	fn                             HandlerFunc
+	isCreation                     bool
+	errorRepresentationConstructor ErrorConstructor
+	errorDecider                   Decider
+	errorStatus                    func(error) int
+	logger                         *zap.Logger
+}
+
+// Handler constructs an HTTP handler that negotiates the representations
+// provided by the handler function with the provided negotiator.
+//
+// Errors encountered are mapped to an HTTP status code and responded with
+// using a representation describing the error.
+func Handler(n Negotiator, fn HandlerFunc, options ...HandlerOption) http.Handler {
+	o := handlerOptions(options...)
+	return handler{
+		negotiator:                     n,
+		fn:                             fn,
+		isCreation:                     o.IsCreation,
+		errorRepresentationConstructor: o.ErrorRepresentationConstructor,
+		errorDecider:                   o.ErrorDecider,
+		errorStatus:                    o.ErrorStatus,
+		logger:                         o.Logger,
+	}
+}
+
+// handlerOptions applies the provided options to the default handler
+// options.
+func handlerOptions(options ...HandlerOption) HandlerOptions {
+	// set defaults.
+	o := HandlerOptions{
+		ErrorRepresentationConstructor: errorRepresentations,
+		ErrorStatus:                    DefaultErrorStatus,
+		Logger:                         zap.NewNop(),
+	}
+	// apply options.
+	for _, opt := range options {
+		opt(&o)
+	}
+	return o
+}
+
+// HandlerOf constructs an HTTP handler that negotiates the value provided by
+// the handler function with the provided negotiator. Values that are not
+// already representations are represented with representation.Of, which can
+// be configured with the ValueOptions option.
+func HandlerOf[T any](
+	n Negotiator, fn func(*http.Request) (T, error), options ...HandlerOption,
+) http.Handler {
+	o := handlerOptions(options...)
+	return Handler(n, func(r *http.Request) ([]representation.Representation, error) {
+		value, err := fn(r)
+		if err != nil {
+			return nil, err
+		}
+		if rep, ok := any(value).(representation.Representation); ok {
+			return []representation.Representation{rep}, nil
+		}
+		return representation.Of(value, o.ValueOptions...)
+	}, options...)
+}
+
+// Adapter constructs an adapter that turns handler functions into HTTP
+// handlers negotiating the representations they provide with the provided
+// negotiator. Unlike middleware, it adapts handler functions rather than
+// wrapping HTTP handlers.
+func Adapter(n Negotiator, options ...HandlerOption) func(HandlerFunc) http.Handler {
+	return func(fn HandlerFunc) http.Handler {
+		return Handler(n, fn, options...)
+	}
+}
+
+// ServeHTTP responds to the request with the representation chosen during
+// content negotiation.
+func (h handler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	reps, err := h.fn(r)
+	if err != nil {
+		h.error(rw, r, err)
+		return
+	}
+
+	// negotiators unable to decide respond to the user agent directly, in
+	// which case errors are only responded with when nothing was written.
+	decider, ok := h.negotiator.(Decider)
+	if !ok {
+		w := &responseWriter{ResponseWriter: rw}
+		ctx := NegotiationContext{Request: r, ResponseWriter: w, IsCreation: h.isCreation}
+		if err = h.negotiator.Negotiate(ctx, reps...); err != nil {
+			if w.written {
+				h.logger.Error("failed to negotiate after responding", zap.Error(err))
+				return
+			}
+			h.error(rw, r, err)
+		}
+		return
+	}
+
+	var d Decision
+	if d, err = decider.Decide(r, reps...); err != nil {
+		h.error(rw, r, err)
+		return
+	}
+	if h.isCreation && d.Outcome == OutcomeAcceptable {
+		d.StatusCode = http.StatusCreated
+	}
+	if _, err = d.WriteTo(rw); err != nil {
+		h.logger.Error("failed to write response", zap.Error(err))
+	}
+}
+
+// error responds to the user agent with a representation describing the
+// provided error.
+func (h handler) error(rw http.ResponseWriter, r *http.Request, err error) {
+	status := h.errorStatus(err)
+	h.logger.Error("failed to handle request", zap.Error(err), zap.Int("status", status))
+
+	d := Decision{StatusCode: status, Header: make(http.Header)}
+	reps := h.errorRepresentationConstructor(status, err)
+	if h.errorDecider != nil {
+		if dd, derr := h.errorDecider.Decide(r, reps...); derr == nil && dd.Representation != nil {
+			d.Representation, d.Header = dd.Representation, dd.Header
+		}
+	}
+	if d.Representation == nil && len(reps) > 0 {
+		d.Representation = acceptableError(r, reps)
+		d.Header = ContentHeaders(d.Representation)
+		d.Header.Set("Vary", representation.DimensionMediaType.String())
+	}
+	if _, err = d.WriteTo(rw); err != nil {
+		h.logger.Error("failed to write error response", zap.Error(err))
+	}
+}
+
+// acceptableError chooses the error representation whose media type is most
+// acceptable to the user agent according to the Accept header. Rather than
+// responding with 406 Not Acceptable, the first error representation is
+// chosen when none of them are acceptable or the Accept header is absent or
+// malformed.
+func acceptableError(
+	r *http.Request, reps []representation.Representation,
+) representation.Representation {
+	accept, err := header.NewAccept(r.Header["Accept"])
+	if err != nil || accept.IsEmpty() {
+		return reps[0]
+	}
+
+	var (
+		chosen = reps[0]
+		best   = header.QualityValueMinimum
+	)
+	for _, rep := range reps {
+		// the most specific media range determines the quality value.
+		var (
+			q          = header.QualityValueMinimum
+			precedence = -1
+		)
+		for _, mr := range accept.MediaRanges() {
+			compatible, err := mr.Compatible(rep.ContentType())
+			if err != nil || !compatible || mr.Precedence() <= precedence {
+				continue
+			}
+			q, precedence = mr.QualityValue(), mr.Precedence()
+		}
+		if q.GreaterThan(best) {
+			chosen, best = rep, q
+		}
+	}
+	return chosen
+}
+
+// responseWriter represents an http.ResponseWriter that records whether the
+// response has been written.
+type responseWriter struct {
+	http.ResponseWriter
+	written bool
+}
+
+// WriteHeader writes the headers and the provided HTTP status code.
+func (w *responseWriter) WriteHeader(status int) {
+	w.written = true
+	w.ResponseWriter.WriteHeader(status)
+}
+
+// Write writes the provided bytes as part of the response body.
+func (w *responseWriter) Write(b []byte) (int, error) {
+	w.written = true
+	return w.ResponseWriter.Write(b)
+}
+
+// Unwrap provides the underlying http.ResponseWriter, which allows
+// http.ResponseController to reach it.
+func (w *responseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
@@ -0,0 +1,89 @@
+/* Copyright 2020 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package negotiator
+
+import (
+	"github.com/freerware/negotiator/representation"
+	"go.uber.org/zap"
+)
+
+// HandlerOptions represents the configuration options for handlers that
+// perform content negotiation.
+type HandlerOptions struct {
+	IsCreation                     bool
+	ErrorRepresentationConstructor ErrorConstructor
+	ErrorDecider                   Decider
+	ErrorStatus                    func(error) int
+	ValueOptions                   []representation.OfOption
+	Logger                         *zap.Logger
+}
+
+// HandlerOption represents a configurable option for handlers that perform
+// content negotiation.
+type HandlerOption func(*HandlerOptions)
+
+// Options that can be used to configure handlers.
+var (
+	// Creation indicates the handler is responsible for creating the
+	// resource, which results in a 201 HTTP status code for responses
+	// containing an acceptable representation.
+	Creation = func() HandlerOption {
+		return func(o *HandlerOptions) {
+			o.IsCreation = true
+		}
+	}
+
+	// ErrorRepresentations defines the representations to consider when
+	// responding to the user agent with an error.
+	ErrorRepresentations = func(constructor ErrorConstructor) HandlerOption {
+		return func(o *HandlerOptions) {
+			o.ErrorRepresentationConstructor = constructor
+		}
+	}
+
+	// ErrorNegotiator defines the negotiator utilized to choose amongst the
+	// error representations. When not provided, the error representation
+	// whose media type is most acceptable to the user agent is utilized,
+	// falling back to the first error representation.
+	ErrorNegotiator = func(d Decider) HandlerOption {
+		return func(o *HandlerOptions) {
+			o.ErrorDecider = d
+		}
+	}
+
+	// ErrorStatus defines how errors encountered are mapped to HTTP status
+	// codes.
+	ErrorStatus = func(status func(error) int) HandlerOption {
+		return func(o *HandlerOptions) {
+			o.ErrorStatus = status
+		}
+	}
+
+	// ValueOptions defines how the values provided to handlers constructed
+	// with HandlerOf are represented.
+	ValueOptions = func(options ...representation.OfOption) HandlerOption {
+		return func(o *HandlerOptions) {
+			o.ValueOptions = options
+		}
+	}
+
+	// HandlerLogger specifies the logger for the handler.
+	HandlerLogger = func(l *zap.Logger) HandlerOption {
+		return func(o *HandlerOptions) {
+			o.Logger = l
+		}
+	}
+)
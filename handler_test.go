@@ -0,0 +1,334 @@
+/* Copyright 2020 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package negotiator_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/freerware/negotiator"
+	"github.com/freerware/negotiator/internal/test"
+	"github.com/freerware/negotiator/proactive"
+	"github.com/freerware/negotiator/reactive"
+	"github.com/freerware/negotiator/representation"
+	"github.com/stretchr/testify/suite"
+)
+
+type HandlerTestSuite struct {
+	suite.Suite
+}
+
+func TestHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(HandlerTestSuite))
+}
+
+func (s HandlerTestSuite) thing(id string) *test.Representation {
+	r := test.Representation{A: id}
+	r.SetContentType("application/json")
+	r.SetContentLanguage("en-US")
+	r.SetContentCharset("ascii")
+	r.SetSourceQuality(1.0)
+	return &r
+}
+
+func (s HandlerTestSuite) TestHandler_ServeMux() {
+	// arrange.
+	mux := http.NewServeMux()
+	mux.Handle("GET /things/{id}", negotiator.Handler(
+		proactive.Default,
+		func(r *http.Request) ([]representation.Representation, error) {
+			return []representation.Representation{s.thing(r.PathValue("id"))}, nil
+		},
+	))
+	request := httptest.NewRequest("GET", "http://freer.ddns.net/things/42", nil)
+	request.Header.Add("Accept", "application/json")
+	responseWriter := httptest.NewRecorder()
+
+	// action.
+	mux.ServeHTTP(responseWriter, request)
+
+	// assert.
+	response := responseWriter.Result()
+	s.Equal(http.StatusOK, response.StatusCode)
+	s.Equal("application/json", response.Header.Get("Content-Type"))
+	var body test.Representation
+	s.Require().NoError(json.Unmarshal(responseWriter.Body.Bytes(), &body))
+	s.Equal("42", body.A)
+}
+
+func (s HandlerTestSuite) TestHandler_InvalidHeader() {
+	// arrange.
+	h := negotiator.Handler(
+		proactive.Default,
+		func(r *http.Request) ([]representation.Representation, error) {
+			return []representation.Representation{s.thing("42")}, nil
+		},
+	)
+	request := httptest.NewRequest("GET", "http://freer.ddns.net/things/42", nil)
+	request.Header.Add("Accept", "text/html;q=abc")
+	responseWriter := httptest.NewRecorder()
+
+	// action.
+	h.ServeHTTP(responseWriter, request)
+
+	// assert.
+	response := responseWriter.Result()
+	s.Equal(http.StatusBadRequest, response.StatusCode)
+	s.Equal(representation.ProblemJSON, response.Header.Get("Content-Type"))
+	var body map[string]interface{}
+	s.Require().NoError(json.Unmarshal(responseWriter.Body.Bytes(), &body))
+	s.Equal(float64(http.StatusBadRequest), body["status"])
+	s.Equal(http.StatusText(http.StatusBadRequest), body["title"])
+	s.Contains(body["detail"], "Accept")
+}
+
+func (s HandlerTestSuite) TestHandler_Error() {
+	// arrange.
+	h := negotiator.Handler(
+		proactive.Default,
+		func(r *http.Request) ([]representation.Representation, error) {
+			return nil, errors.New("whoa")
+		},
+		negotiator.ErrorNegotiator(proactive.Default),
+	)
+	request := httptest.NewRequest("GET", "http://freer.ddns.net/things/42", nil)
+	request.Header.Add("Accept", representation.ProblemXML)
+	responseWriter := httptest.NewRecorder()
+
+	// action.
+	h.ServeHTTP(responseWriter, request)
+
+	// assert.
+	response := responseWriter.Result()
+	s.Equal(http.StatusInternalServerError, response.StatusCode)
+	s.Equal(representation.ProblemXML, response.Header.Get("Content-Type"))
+	s.Contains(response.Header.Get("Vary"), "Accept")
+	s.True(strings.HasPrefix(responseWriter.Body.String(), `<problem xmlns="urn:ietf:rfc:7807">`))
+	s.NotContains(responseWriter.Body.String(), "whoa")
+}
+
+func (s HandlerTestSuite) TestHandler_Error_Accept() {
+	tests := []struct {
+		name        string
+		accept      []string
+		contentType string
+	}{
+		{"MissingAccept", nil, representation.ProblemJSON},
+		{"ProblemXML", []string{representation.ProblemXML}, representation.ProblemXML},
+		{"ProblemJSON", []string{representation.ProblemJSON}, representation.ProblemJSON},
+		{"Preferred", []string{"application/problem+json;q=0.5, application/problem+xml"}, representation.ProblemXML},
+		{"MostSpecific", []string{"application/*, application/problem+json;q=0.1"}, representation.ProblemXML},
+		{"NotAcceptable", []string{"text/html"}, representation.ProblemJSON},
+		{"InvalidAccept", []string{"text/html;q=abc"}, representation.ProblemJSON},
+	}
+	for _, test := range tests {
+		s.Run(test.name, func() {
+			// arrange.
+			h := negotiator.Handler(
+				proactive.Default,
+				func(r *http.Request) ([]representation.Representation, error) {
+					return nil, errors.New("whoa")
+				},
+			)
+			request := httptest.NewRequest("GET", "http://freer.ddns.net/things/42", nil)
+			request.Header["Accept"] = test.accept
+			responseWriter := httptest.NewRecorder()
+
+			// action.
+			h.ServeHTTP(responseWriter, request)
+
+			// assert.
+			response := responseWriter.Result()
+			s.Equal(http.StatusInternalServerError, response.StatusCode)
+			s.Equal(test.contentType, response.Header.Get("Content-Type"))
+			s.Equal("Accept", response.Header.Get("Vary"))
+		})
+	}
+}
+
+func (s HandlerTestSuite) TestHandler_ErrorStatus() {
+	// arrange.
+	errNotFound := errors.New("not found")
+	h := negotiator.Handler(
+		proactive.Default,
+		func(r *http.Request) ([]representation.Representation, error) {
+			return nil, errNotFound
+		},
+		negotiator.ErrorStatus(func(err error) int {
+			if errors.Is(err, errNotFound) {
+				return http.StatusNotFound
+			}
+			return negotiator.DefaultErrorStatus(err)
+		}),
+		negotiator.ErrorRepresentations(func(status int, err error) []representation.Representation {
+			return []representation.Representation{s.thing(err.Error())}
+		}),
+	)
+	request := httptest.NewRequest("GET", "http://freer.ddns.net/things/42", nil)
+	responseWriter := httptest.NewRecorder()
+
+	// action.
+	h.ServeHTTP(responseWriter, request)
+
+	// assert.
+	response := responseWriter.Result()
+	s.Equal(http.StatusNotFound, response.StatusCode)
+	var body test.Representation
+	s.Require().NoError(json.Unmarshal(responseWriter.Body.Bytes(), &body))
+	s.Equal(errNotFound.Error(), body.A)
+}
+
+func (s HandlerTestSuite) TestHandler_Creation() {
+	// arrange.
+	h := negotiator.HandlerOf(
+		proactive.Default,
+		func(r *http.Request) (*test.Representation, error) {
+			return s.thing("42"), nil
+		},
+		negotiator.Creation(),
+	)
+	request := httptest.NewRequest("POST", "http://freer.ddns.net/things", nil)
+	responseWriter := httptest.NewRecorder()
+
+	// action.
+	h.ServeHTTP(responseWriter, request)
+
+	// assert.
+	response := responseWriter.Result()
+	s.Equal(http.StatusCreated, response.StatusCode)
+	s.NotZero(responseWriter.Body.Len())
+}
+
+func (s HandlerTestSuite) TestHandlerOf_Value() {
+	// arrange.
+	type thing struct {
+		ID string `json:"id" xml:"id"`
+	}
+	h := negotiator.HandlerOf(
+		proactive.Default,
+		func(r *http.Request) (thing, error) {
+			return thing{ID: r.PathValue("id")}, nil
+		},
+		negotiator.ValueOptions(
+			representation.MediaTypes("application/json", "application/xml"),
+			representation.Language("en-US"),
+		),
+	)
+	mux := http.NewServeMux()
+	mux.Handle("GET /things/{id}", h)
+	request := httptest.NewRequest("GET", "http://freer.ddns.net/things/42", nil)
+	request.Header.Add("Accept", "application/json")
+	responseWriter := httptest.NewRecorder()
+
+	// action.
+	mux.ServeHTTP(responseWriter, request)
+
+	// assert.
+	response := responseWriter.Result()
+	s.Equal(http.StatusOK, response.StatusCode)
+	s.Equal("application/json", response.Header.Get("Content-Type"))
+	s.JSONEq(`{"id":"42"}`, responseWriter.Body.String())
+}
+
+func (s HandlerTestSuite) TestAdapter() {
+	// arrange.
+	adapt := negotiator.Adapter(reactive.Default)
+	h := adapt(func(r *http.Request) ([]representation.Representation, error) {
+		return []representation.Representation{s.thing("1"), s.thing("2")}, nil
+	})
+	request := httptest.NewRequest("GET", "http://freer.ddns.net/things", nil)
+	responseWriter := httptest.NewRecorder()
+
+	// action.
+	h.ServeHTTP(responseWriter, request)
+
+	// assert.
+	response := responseWriter.Result()
+	s.Equal(http.StatusMultipleChoices, response.StatusCode)
+	s.Equal("application/json", response.Header.Get("Content-Type"))
+}
+
+func (s HandlerTestSuite) TestHandler_Negotiator() {
+	// arrange.
+	errExpected := errors.New("whoa")
+	n := negotiatorFunc(func(ctx negotiator.NegotiationContext, reps ...representation.Representation) error {
+		return errExpected
+	})
+	h := negotiator.Handler(n, func(r *http.Request) ([]representation.Representation, error) {
+		return []representation.Representation{s.thing("42")}, nil
+	})
+	request := httptest.NewRequest("GET", "http://freer.ddns.net/things/42", nil)
+	responseWriter := httptest.NewRecorder()
+
+	// action.
+	h.ServeHTTP(responseWriter, request)
+
+	// assert.
+	response := responseWriter.Result()
+	s.Equal(http.StatusInternalServerError, response.StatusCode)
+}
+
+func (s HandlerTestSuite) TestHandler_Negotiator_Written() {
+	// arrange.
+	n := negotiatorFunc(func(ctx negotiator.NegotiationContext, reps ...representation.Representation) error {
+		ctx.ResponseWriter.WriteHeader(http.StatusOK)
+		ctx.ResponseWriter.Write([]byte("partial"))
+		return errors.New("whoa")
+	})
+	h := negotiator.Handler(n, func(r *http.Request) ([]representation.Representation, error) {
+		return []representation.Representation{s.thing("42")}, nil
+	})
+	request := httptest.NewRequest("GET", "http://freer.ddns.net/things/42", nil)
+	responseWriter := httptest.NewRecorder()
+
+	// action.
+	h.ServeHTTP(responseWriter, request)
+
+	// assert.
+	response := responseWriter.Result()
+	s.Equal(http.StatusOK, response.StatusCode)
+	s.Equal("partial", responseWriter.Body.String())
+	s.Empty(response.Header.Get("Content-Type"))
+}
+
+func (s HandlerTestSuite) TestDefaultErrorStatus() {
+	tests := []struct {
+		name string
+		in   error
+		out  int
+	}{
+		{"InvalidHeader", negotiator.ErrInvalidHeader, http.StatusBadRequest},
+		{"WrappedInvalidHeader", errors.Join(errors.New("whoa"), negotiator.ErrInvalidHeader), http.StatusBadRequest},
+		{"Other", errors.New("whoa"), http.StatusInternalServerError},
+	}
+
+	for _, test := range tests {
+		s.Run(test.name, func() {
+			// action + assert.
+			s.Equal(test.out, negotiator.DefaultErrorStatus(test.in))
+		})
+	}
+}
+
+type negotiatorFunc func(negotiator.NegotiationContext, ...representation.Representation) error
+
+func (f negotiatorFunc) Negotiate(ctx negotiator.NegotiationContext, reps ...representation.Representation) error {
+	return f(ctx, reps...)
+}
@@ -26,10 +26,10 @@ var (
 	headerAccept = "Accept"
 
 	// DefaultAccept is an Accept header with a single media range of "*/*".
-	DefaultAccept = Accept([]MediaRange{defaultMediaRange})
+	DefaultAccept = newAccept([]MediaRange{defaultMediaRange})
 
 	// EmptyAccept is an empty Accept header.
-	EmptyAccept = Accept([]MediaRange{})
+	EmptyAccept = newAccept([]MediaRange{})
 )
 
 // Accept represents the Accept header.
@@ -39,7 +39,32 @@ var (
 // be used to indicate that the request is specifically limited to a
 // small set of desired types, as in the case of a request for an
 // in-line image.
-type Accept []MediaRange
+//
+// An Accept header is immutable once constructed, and therefore safe for
+// concurrent use.
+type Accept struct {
+	// mediaRanges are the media ranges in the order they were specified.
+	mediaRanges []MediaRange
+
+	// sorted are the media ranges sorted on preference and precedence.
+	sorted []MediaRange
+}
+
+// newAccept constructs an Accept header with the provided media ranges,
+// sorting them on preference and precedence from highest to lowest.
+func newAccept(mediaRanges []MediaRange) Accept {
+	sorted := append([]MediaRange{}, mediaRanges...)
+	sort.SliceStable(sorted, func(first, second int) bool {
+		f := sorted[first]
+		s := sorted[second]
+
+		if f.QualityValue().Equals(s.QualityValue()) {
+			return f.Precedence() > s.Precedence()
+		}
+		return f.QualityValue().GreaterThan(s.QualityValue())
+	})
+	return Accept{mediaRanges: mediaRanges, sorted: sorted}
+}
 
 // NewAccept constructs an Accept header with the provided media ranges.
 func NewAccept(accept []string) (Accept, error) {
@@ -49,35 +74,26 @@ func NewAccept(accept []string) (Accept, error) {
 
 	// parse media ranges
 	var mediaRanges []MediaRange
-	for _, m := range accept {
-		mediaRange, err := parseMediaRange(m)
+	for _, e := range elements(accept) {
+		mediaRange, err := parseMediaRange(e.value)
 		if err != nil {
-			return EmptyAccept, err
+			return EmptyAccept, invalid(headerAccept, e.line, e.offset, err)
 		}
 		mediaRanges = append(mediaRanges, mediaRange)
 	}
-	return Accept(mediaRanges), nil
+	return newAccept(mediaRanges), nil
 }
 
 // MediaRanges provides the media ranges sorted on preference and precedence,
 // from highest preference and precedence to lowest.
 func (a Accept) MediaRanges() []MediaRange {
-	sort.Slice(a, func(first, second int) bool {
-		f := a[first]
-		s := a[second]
-
-		if f.QualityValue().Equals(s.QualityValue()) {
-			return f.Precedence() > s.Precedence()
-		}
-		return f.QualityValue().GreaterThan(s.QualityValue())
-	})
-	return a
+	return append([]MediaRange(nil), a.sorted...)
 }
 
 // Compatible determines if the provided media type is compatible with any
 // of the media ranges within the Accept header value.
 func (a Accept) Compatible(mediaType string) (c bool, err error) {
-	for _, r := range a.MediaRanges() {
+	for _, r := range a.sorted {
 		if c, err = r.Compatible(mediaType); err != nil || c {
 			return
 		}
@@ -87,14 +103,20 @@ func (a Accept) Compatible(mediaType string) (c bool, err error) {
 
 // IsEmpty indicates if the Accept header is empty.
 func (a Accept) IsEmpty() bool {
-	return len(a) == len(EmptyAccept)
+	return len(a.mediaRanges) == 0
 }
 
-// String provides a textual representation of the Accept header.
-func (a Accept) String() string {
+// ValuesAsString provides the value of the Accept header, which can be
+// parsed to construct an equivalent Accept header.
+func (a Accept) ValuesAsString() string {
 	var mediaRanges []string
-	for _, mr := range a {
+	for _, mr := range a.mediaRanges {
 		mediaRanges = append(mediaRanges, mr.String())
 	}
-	return fmt.Sprintf("%s: %s", headerAccept, strings.Join(mediaRanges, ","))
+	return strings.Join(mediaRanges, ",")
+}
+
+// String provides a textual representation of the Accept header.
+func (a Accept) String() string {
+	return fmt.Sprintf("%s: %s", headerAccept, a.ValuesAsString())
 }
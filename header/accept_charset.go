@@ -27,10 +27,10 @@ var (
 
 	// DefaultAcceptCharset is an Accept-Charset header with a charset range
 	// of "*" and quality value of 1.0.
-	DefaultAcceptCharset = AcceptCharset([]CharsetRange{defaultCharsetRange})
+	DefaultAcceptCharset = newAcceptCharset([]CharsetRange{defaultCharsetRange})
 
 	// EmptyAcceptCharset is an empty Accept-Charset header.
-	EmptyAcceptCharset = AcceptCharset([]CharsetRange{})
+	EmptyAcceptCharset = newAcceptCharset([]CharsetRange{})
 )
 
 // AcceptCharset represents the Accept-Charset header.
@@ -41,7 +41,28 @@ var (
 // comprehensive or special-purpose charsets to signal that capability
 // to an origin server that is capable of representing information in
 // those charsets.
-type AcceptCharset []CharsetRange
+//
+// An Accept-Charset header is immutable once constructed, and therefore safe
+// for concurrent use.
+type AcceptCharset struct {
+	// charsets are the charset ranges in the order they were specified.
+	charsets []CharsetRange
+
+	// sorted are the charset ranges sorted on preference.
+	sorted []CharsetRange
+}
+
+// newAcceptCharset constructs an Accept-Charset header with the provided
+// charset ranges, sorting them on preference from highest to lowest.
+func newAcceptCharset(charsets []CharsetRange) AcceptCharset {
+	sorted := append([]CharsetRange{}, charsets...)
+	sort.SliceStable(sorted, func(first, second int) bool {
+		f := sorted[first]
+		s := sorted[second]
+		return f.QualityValue().GreaterThan(s.QualityValue())
+	})
+	return AcceptCharset{charsets: charsets, sorted: sorted}
+}
 
 // NewAcceptCharset constructs an Accept-Charset header with the provided
 // charsets.
@@ -50,31 +71,26 @@ func NewAcceptCharset(acceptCharset []string) (AcceptCharset, error) {
 		return EmptyAcceptCharset, nil
 	}
 	var charsets []CharsetRange
-	for _, c := range acceptCharset {
-		charset, err := NewCharsetRange(c)
+	for _, e := range elements(acceptCharset) {
+		charset, err := NewCharsetRange(e.value)
 		if err != nil {
-			return EmptyAcceptCharset, err
+			return EmptyAcceptCharset, invalid(headerAcceptCharset, e.line, e.offset, err)
 		}
 		charsets = append(charsets, charset)
 	}
-	return AcceptCharset(charsets), nil
+	return newAcceptCharset(charsets), nil
 }
 
 // CharsetRanges provides the charsets sorted on preference from highest
 // preference to lowest.
 func (c AcceptCharset) CharsetRanges() []CharsetRange {
-	sort.Slice(c, func(first, second int) bool {
-		f := c[first]
-		s := c[second]
-		return f.QualityValue().GreaterThan(s.QualityValue())
-	})
-	return c
+	return append([]CharsetRange(nil), c.sorted...)
 }
 
 // Compatible determines if the provided charset is compatible with any
 // of the charset ranges within the Accept-Charset header value.
 func (c AcceptCharset) Compatible(charset string) (cc bool, err error) {
-	for _, r := range c.CharsetRanges() {
+	for _, r := range c.sorted {
 		if cc = r.Compatible(charset); err != nil || cc {
 			return
 		}
@@ -84,14 +100,20 @@ func (c AcceptCharset) Compatible(charset string) (cc bool, err error) {
 
 // IsEmpty indicates if the Accept-Charset header is empty.
 func (c AcceptCharset) IsEmpty() bool {
-	return len(c) == len(EmptyAcceptCharset)
+	return len(c.charsets) == 0
 }
 
-// String provides the textual representation of the Accept-Charset header value.
-func (c AcceptCharset) String() string {
+// ValuesAsString provides the value of the Accept-Charset header, which can
+// be parsed to construct an equivalent Accept-Charset header.
+func (c AcceptCharset) ValuesAsString() string {
 	var charsets []string
-	for _, cr := range c {
+	for _, cr := range c.charsets {
 		charsets = append(charsets, cr.String())
 	}
-	return fmt.Sprintf("%s: %s", headerAcceptCharset, strings.Join(charsets, ","))
+	return strings.Join(charsets, ",")
+}
+
+// String provides the textual representation of the Accept-Charset header value.
+func (c AcceptCharset) String() string {
+	return fmt.Sprintf("%s: %s", headerAcceptCharset, c.ValuesAsString())
 }
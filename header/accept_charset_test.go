@@ -3,7 +3,7 @@ package header_test
 import (
 	"testing"
 
-	"github.com/freerware/negotiator/internal/header"
+	"github.com/freerware/negotiator/header"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -27,10 +27,10 @@ var (
 
 	// DefaultAcceptEncoding is an Accept-Encoding header value with a
 	// single content coding of "*".
-	DefaultAcceptEncoding = AcceptEncoding([]ContentCodingRange{defaultContentCodingRange})
+	DefaultAcceptEncoding = newAcceptEncoding([]ContentCodingRange{defaultContentCodingRange})
 
 	// EmptyAcceptEncoding is an empty Accept-Encoding header.
-	EmptyAcceptEncoding = AcceptEncoding([]ContentCodingRange{})
+	EmptyAcceptEncoding = newAcceptEncoding([]ContentCodingRange{})
 )
 
 // AcceptEncoding represents the Accept-Encoding header.
@@ -38,7 +38,29 @@ var (
 // The "Accept-Encoding" header field can be used by user agents to
 // indicate what response content-codings (Section 3.1.2.1) are
 // acceptable in the response.
-type AcceptEncoding []ContentCodingRange
+//
+// An Accept-Encoding header is immutable once constructed, and therefore
+// safe for concurrent use.
+type AcceptEncoding struct {
+	// codings are the content coding ranges in the order they were
+	// specified.
+	codings []ContentCodingRange
+
+	// sorted are the content coding ranges sorted on preference.
+	sorted []ContentCodingRange
+}
+
+// newAcceptEncoding constructs an Accept-Encoding header with the provided
+// content coding ranges, sorting them on preference from highest to lowest.
+func newAcceptEncoding(codings []ContentCodingRange) AcceptEncoding {
+	sorted := append([]ContentCodingRange{}, codings...)
+	sort.SliceStable(sorted, func(first, second int) bool {
+		f := sorted[first]
+		s := sorted[second]
+		return f.QualityValue().GreaterThan(s.QualityValue())
+	})
+	return AcceptEncoding{codings: codings, sorted: sorted}
+}
 
 // NewAcceptEncoding constructs an Accept-Encoding header with the provided
 // content codings.
@@ -47,37 +69,38 @@ func NewAcceptEncoding(acceptEncoding []string) (AcceptEncoding, error) {
 		return EmptyAcceptEncoding, nil
 	}
 	var contentCodings []ContentCodingRange
-	for _, cc := range acceptEncoding {
-		coding, err := NewContentCodingRange(cc)
+	for _, e := range elements(acceptEncoding) {
+		coding, err := NewContentCodingRange(e.value)
 		if err != nil {
-			return EmptyAcceptEncoding, err
+			return EmptyAcceptEncoding, invalid(headerAcceptEncoding, e.line, e.offset, err)
 		}
 		contentCodings = append(contentCodings, coding)
 	}
-	return AcceptEncoding(contentCodings), nil
+	return newAcceptEncoding(contentCodings), nil
 }
 
 // Codings provides the content codings sorted on preference from highest
 // preference to lowest.
 func (e AcceptEncoding) CodingRanges() []ContentCodingRange {
-	sort.Slice(e, func(first, second int) bool {
-		f := e[first]
-		s := e[second]
-		return f.QualityValue().GreaterThan(s.QualityValue())
-	})
-	return e
+	return append([]ContentCodingRange(nil), e.sorted...)
 }
 
 // IsEmpty indicates if the Accept-Encoding header is empty.
 func (e AcceptEncoding) IsEmpty() bool {
-	return len(e) == len(EmptyAcceptEncoding)
+	return len(e.codings) == 0
 }
 
-// String provides a textual representation of the Accept-Encoding header.
-func (e AcceptEncoding) String() string {
+// ValuesAsString provides the value of the Accept-Encoding header, which can
+// be parsed to construct an equivalent Accept-Encoding header.
+func (e AcceptEncoding) ValuesAsString() string {
 	var codings []string
-	for _, c := range e {
+	for _, c := range e.codings {
 		codings = append(codings, c.String())
 	}
-	return fmt.Sprintf("%s: %s", headerAcceptEncoding, strings.Join(codings, ","))
+	return strings.Join(codings, ",")
+}
+
+// String provides a textual representation of the Accept-Encoding header.
+func (e AcceptEncoding) String() string {
+	return fmt.Sprintf("%s: %s", headerAcceptEncoding, e.ValuesAsString())
 }
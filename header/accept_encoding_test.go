@@ -3,7 +3,7 @@ package header_test
 import (
 	"testing"
 
-	"github.com/freerware/negotiator/internal/header"
+	"github.com/freerware/negotiator/header"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -23,7 +23,8 @@ func (s AcceptEncodingTestSuite) TestAcceptEncoding_NewAcceptEncoding() {
 	}{
 		{"SingleRange", []string{"gzip"}, nil},
 		{"MultipleRanges", []string{"gzip", "compress"}, nil},
-		{"InvalidContentCodingRange", []string{"zippy;q=0.5"}, header.ErrInvalidContentCodingRange},
+		{"CommaSeparated", []string{"gzip, deflate, br, zstd"}, nil},
+		{"InvalidContentCodingRange", []string{"gzip, zippy;q=abc"}, header.ErrInvalidContentCodingRange},
 		{"Empty", []string{}, nil},
 	}
 
@@ -34,7 +35,7 @@ func (s AcceptEncodingTestSuite) TestAcceptEncoding_NewAcceptEncoding() {
 
 			// assert.
 			if test.err != nil {
-				s.Require().EqualError(err, test.err.Error())
+				s.Require().ErrorIs(err, test.err)
 				s.NotZero(ae)
 			} else {
 				s.Require().NoError(err)
@@ -28,7 +28,7 @@ var (
 	DefaultAcceptFeatures = EmptyAcceptFeatures
 
 	// EmptyAcceptFeatures is an empty Accept-Features header.
-	EmptyAcceptFeatures = AcceptFeatures([]FeatureExpression{})
+	EmptyAcceptFeatures = AcceptFeatures{expressions: []FeatureExpression{}}
 )
 
 // AcceptFeatures represents the Accept-Features header.
@@ -37,7 +37,12 @@ var (
 // give information about the presence or absence of certain features in
 // the feature set of the current request.  Servers can use this
 // information when running a remote variant selection algorithm.
-type AcceptFeatures []FeatureExpression
+//
+// An Accept-Features header is immutable once constructed, and therefore
+// safe for concurrent use.
+type AcceptFeatures struct {
+	expressions []FeatureExpression
+}
 
 // NewAcceptFeatures constructs an Accept-Features header with the provided
 // feature expressions.
@@ -46,15 +51,29 @@ func NewAcceptFeatures(acceptFeatures []string) (AcceptFeatures, error) {
 		return EmptyAcceptFeatures, nil
 	}
 	var expressions []FeatureExpression
-	for _, e := range acceptFeatures {
-		expressions = append(expressions, FeatureExpression(e))
+	for _, e := range elements(acceptFeatures) {
+		expressions = append(expressions, FeatureExpression(e.value))
 	}
-	return AcceptFeatures(expressions), nil
+	return AcceptFeatures{expressions: expressions}, nil
 }
 
 // Empty indicates if the Accept-Header is empty.
 func (f AcceptFeatures) IsEmpty() bool {
-	return len(f) == len(EmptyAcceptFeatures)
+	return len(f.expressions) == 0
+}
+
+// ContainsWildcard indicates if the Accept-Features header contains the '*'
+// feature expression, which communicates that the feature expressions do not
+// fully describe the feature set of the user agent.
+//
+// https://tools.ietf.org/html/rfc2295#section-8.2
+func (f AcceptFeatures) ContainsWildcard() bool {
+	for _, e := range f.expressions {
+		if e.IsWildcard() {
+			return true
+		}
+	}
+	return false
 }
 
 // AsFeatureSets utilizes the feature expressions within the Accept-Feature
@@ -62,7 +81,7 @@ func (f AcceptFeatures) IsEmpty() bool {
 // unsupported feature sets.
 func (f AcceptFeatures) AsFeatureSets() (supported, unsupported FeatureSet) {
 	supported, unsupported = make(FeatureSet), make(FeatureSet)
-	for _, e := range f {
+	for _, e := range f.expressions {
 		switch e.Type() {
 		case FeatureExpressionTypeExists:
 			supported.Add(e.Tag())
@@ -79,11 +98,44 @@ func (f AcceptFeatures) AsFeatureSets() (supported, unsupported FeatureSet) {
 	return
 }
 
-// String provides a textual representation of the Accept-Features header.
-func (f AcceptFeatures) String() string {
+// QualityDegradation computes the quality degradation factor for the provided
+// feature list based on the feature sets described by the Accept-Features
+// header, along with an indication of whether the factor was guessed.
+//
+// Feature tags absent from the header are unsupported when the header fully
+// describes the feature set. Otherwise, which is the case for the '*' feature
+// expression or an empty header, predicates on such feature tags are guessed
+// to be false.
+//
+// https://tools.ietf.org/html/rfc2295#section-6.4
+func (f AcceptFeatures) QualityDegradation(fl FeatureList) (float32, bool) {
+	partial := f.IsEmpty() || f.ContainsWildcard()
+	supported, unsupported := f.AsFeatureSets()
+	var guessed bool
+	for _, tag := range fl.Tags() {
+		if supported.Contains(tag) || unsupported.Contains(tag) {
+			continue
+		}
+		if partial {
+			guessed = true
+			continue
+		}
+		unsupported.Add(tag)
+	}
+	return fl.QualityDegradation(supported, unsupported), guessed
+}
+
+// ValuesAsString provides the value of the Accept-Features header, which can
+// be parsed to construct an equivalent Accept-Features header.
+func (f AcceptFeatures) ValuesAsString() string {
 	var expressions []string
-	for _, e := range f {
+	for _, e := range f.expressions {
 		expressions = append(expressions, e.String())
 	}
-	return fmt.Sprintf("%s: %s", headerAcceptFeatures, strings.Join(expressions, ","))
+	return strings.Join(expressions, ",")
+}
+
+// String provides a textual representation of the Accept-Features header.
+func (f AcceptFeatures) String() string {
+	return fmt.Sprintf("%s: %s", headerAcceptFeatures, f.ValuesAsString())
 }
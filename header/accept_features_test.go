@@ -18,7 +18,7 @@ package header_test
 import (
 	"testing"
 
-	"github.com/freerware/negotiator/internal/header"
+	"github.com/freerware/negotiator/header"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -96,3 +96,81 @@ func (s AcceptFeaturesTestSuite) TestAcceptFeatures_String() {
 		})
 	}
 }
+
+func (s AcceptFeaturesTestSuite) TestAcceptFeatures_ContainsWildcard() {
+	tests := []struct {
+		name string
+		in   []string
+		out  bool
+	}{
+		{"Empty", []string{}, false},
+		{"WithoutWildcard", []string{"foo, !bar"}, false},
+		{"WithWildcard", []string{"foo, !bar, *"}, true},
+	}
+
+	for _, test := range tests {
+		s.Run(test.name, func() {
+			// action + assert.
+			af, err := header.NewAcceptFeatures(test.in)
+			s.Require().NoError(err)
+			s.Equal(test.out, af.ContainsWildcard())
+		})
+	}
+}
+
+func (s AcceptFeaturesTestSuite) TestAcceptFeatures_AsFeatureSets() {
+	// arrange.
+	af, err := header.NewAcceptFeatures([]string{
+		"blex, !blebber, colordepth={5}, !screenwidth, papersize=A4, !textwidth, *",
+	})
+	s.Require().NoError(err)
+
+	// action.
+	supported, unsupported := af.AsFeatureSets()
+
+	// assert.
+	s.True(supported.Contains("blex"))
+	s.True(unsupported.Contains("blebber"))
+	colordepth, ok := supported.Values("colordepth")
+	s.True(ok)
+	s.Equal([]header.FeatureTagValue{"5"}, colordepth)
+	papersize, ok := supported.Values("papersize")
+	s.True(ok)
+	s.Equal([]header.FeatureTagValue{"A4"}, papersize)
+	s.True(unsupported.Contains("screenwidth"))
+	s.True(unsupported.Contains("textwidth"))
+	s.False(supported.Contains("*"))
+}
+
+func (s AcceptFeaturesTestSuite) TestAcceptFeatures_QualityDegradation() {
+	tests := []struct {
+		name     string
+		in       []string
+		features []string
+		out      float32
+		guessed  bool
+	}{
+		{"Supported", []string{"tables"}, []string{"tables;+1.5"}, 1.5, false},
+		{"Unsupported", []string{"!tables"}, []string{"tables;-0.5"}, 0.5, false},
+		{"Unmentioned", []string{"frames"}, []string{"!tables"}, 1.0, false},
+		{"UnmentionedWithWildcard", []string{"frames, *"}, []string{"!tables"}, 0.0, true},
+		{"Empty", []string{}, []string{"tables"}, 0.0, true},
+	}
+
+	for _, test := range tests {
+		s.Run(test.name, func() {
+			// arrange.
+			af, err := header.NewAcceptFeatures(test.in)
+			s.Require().NoError(err)
+			fl, err := header.NewFeatureList(test.features)
+			s.Require().NoError(err)
+
+			// action.
+			degradation, guessed := af.QualityDegradation(fl)
+
+			// assert.
+			s.Equal(test.out, degradation)
+			s.Equal(test.guessed, guessed)
+		})
+	}
+}
@@ -19,8 +19,6 @@ import (
 	"fmt"
 	"sort"
 	"strings"
-
-	"golang.org/x/text/language"
 )
 
 var (
@@ -29,10 +27,10 @@ var (
 
 	// DefaultAcceptLanguage is an Accept-Language header with a
 	// single language range of "*".
-	DefaultAcceptLanguage = AcceptLanguage([]LanguageRange{defaultLanguageRange})
+	DefaultAcceptLanguage = newAcceptLanguage([]LanguageRange{defaultLanguageRange})
 
 	// EmptyAcceptLanguage is an empty Accept-Language header.
-	EmptyAcceptLanguage = AcceptLanguage([]LanguageRange{})
+	EmptyAcceptLanguage = newAcceptLanguage([]LanguageRange{})
 )
 
 // AcceptLanguage represents the Accept-Language header.
@@ -40,7 +38,28 @@ var (
 // The "Accept-Language" header field can be used by user agents to
 // indicate the set of natural languages that are preferred in the
 // response.  Language tags are defined in Section 3.1.3.1.
-type AcceptLanguage []LanguageRange
+//
+// An Accept-Language header is immutable once constructed, and therefore
+// safe for concurrent use.
+type AcceptLanguage struct {
+	// ranges are the language ranges in the order they were specified.
+	ranges []LanguageRange
+
+	// sorted are the language ranges sorted on preference.
+	sorted []LanguageRange
+}
+
+// newAcceptLanguage constructs an Accept-Language header with the provided
+// language ranges, sorting them on preference from highest to lowest.
+func newAcceptLanguage(ranges []LanguageRange) AcceptLanguage {
+	sorted := append([]LanguageRange{}, ranges...)
+	sort.SliceStable(sorted, func(first, second int) bool {
+		f := sorted[first]
+		s := sorted[second]
+		return f.QualityValue().GreaterThan(s.QualityValue())
+	})
+	return AcceptLanguage{ranges: ranges, sorted: sorted}
+}
 
 // NewAcceptLanguage constructs an Accept-Language header with the provided
 // language ranges.
@@ -48,45 +67,37 @@ func NewAcceptLanguage(acceptLanguage []string) (AcceptLanguage, error) {
 	if len(acceptLanguage) == 0 {
 		return EmptyAcceptLanguage, nil
 	}
-	tags, qValues, err := language.ParseAcceptLanguage(strings.Join(acceptLanguage, ","))
-	if err != nil {
-		return EmptyAcceptLanguage, err
-	}
-
 	var ranges []LanguageRange
-	for i := 0; i < len(tags); i++ {
-		qv, err := NewQualityValue(qValues[i])
+	for _, e := range elements(acceptLanguage) {
+		lr, err := NewLanguageRange(e.value)
 		if err != nil {
-			return EmptyAcceptLanguage, err
+			return EmptyAcceptLanguage, invalid(headerAcceptLanguage, e.line, e.offset, err)
 		}
-		ranges = append(ranges, LanguageRange{
-			lrange: tags[i].String() + ";q=" + qv.String(),
-			tag:    tags[i],
-			qValue: qv,
-		})
+		ranges = append(ranges, lr)
 	}
-	return AcceptLanguage(ranges), nil
+	return newAcceptLanguage(ranges), nil
 }
 
 // IsEmpty indicates if the Accept-Language header is empty.
 func (l AcceptLanguage) IsEmpty() bool {
-	return len(l) == len(EmptyAcceptLanguage)
+	return len(l.ranges) == 0
 }
 
-// LanguageRanges provides the language ranges sorted on preference from//// highest to lowest.
+// LanguageRanges provides the language ranges sorted on preference from
+// highest to lowest.
 func (l AcceptLanguage) LanguageRanges() []LanguageRange {
-	sort.Slice(l, func(first, second int) bool {
-		f := l[first]
-		s := l[second]
-		return f.QualityValue().GreaterThan(s.QualityValue())
-	})
-	return l
+	return append([]LanguageRange(nil), l.sorted...)
+}
+
+// Specified provides the language ranges in the order they were specified.
+func (l AcceptLanguage) Specified() []LanguageRange {
+	return append([]LanguageRange(nil), l.ranges...)
 }
 
 // Compatible determines if the provided language is compatible with any
 // of the language ranges within the Accept-Language header value.
 func (l AcceptLanguage) Compatible(language string) (c bool, err error) {
-	for _, r := range l.LanguageRanges() {
+	for _, r := range l.sorted {
 		if c = r.Compatible(language); err != nil || c {
 			return
 		}
@@ -94,11 +105,17 @@ func (l AcceptLanguage) Compatible(language string) (c bool, err error) {
 	return
 }
 
-// String provides a textual representation of the Accept-Language header.
-func (l AcceptLanguage) String() string {
+// ValuesAsString provides the value of the Accept-Language header, which can
+// be parsed to construct an equivalent Accept-Language header.
+func (l AcceptLanguage) ValuesAsString() string {
 	var languageRanges []string
-	for _, lr := range l {
-		languageRanges = append(languageRanges, fmt.Sprintf("%s;q=%s", lr.tag.String(), lr.QualityValue().String()))
+	for _, lr := range l.ranges {
+		languageRanges = append(languageRanges, lr.String())
 	}
-	return fmt.Sprintf("%s: %s", headerAcceptLanguage, strings.Join(languageRanges, ","))
+	return strings.Join(languageRanges, ",")
+}
+
+// String provides a textual representation of the Accept-Language header.
+func (l AcceptLanguage) String() string {
+	return fmt.Sprintf("%s: %s", headerAcceptLanguage, l.ValuesAsString())
 }
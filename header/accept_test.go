@@ -18,7 +18,7 @@ package header_test
 import (
 	"testing"
 
-	"github.com/freerware/negotiator/internal/header"
+	"github.com/freerware/negotiator/header"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -130,7 +130,7 @@ func (s AcceptTestSuite) TestAccept_String() {
 		{
 			"SingleRange",
 			[]string{"application/json"},
-			"Accept: application/json;q=1.000",
+			"Accept: application/json",
 		},
 		{
 			"MultipleRanges",
@@ -138,7 +138,7 @@ func (s AcceptTestSuite) TestAccept_String() {
 				"application/json",
 				"application/xml;q=0.8",
 			},
-			"Accept: application/json;q=1.000,application/xml;q=0.800",
+			"Accept: application/json,application/xml;q=0.800",
 		},
 	}
 
@@ -0,0 +1,508 @@
+/* Copyright 2020 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package header
+
+import (
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"mime"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/freerware/negotiator/representation"
+)
+
+var (
+	// headerAlternates is the header key for the Alternates header.
+	headerAlternates = "Alternates"
+
+	// variantAttributeType represents the attribute key that communicates
+	// the media type of the variant.
+	variantAttributeType = "type"
+
+	// variantAttributeCharset represents the attribute key that communicates
+	// the charset of the variant.
+	variantAttributeCharset = "charset"
+
+	// variantAttributeLanguage represents the attribute key that communicates
+	// the language(s) of the variant.
+	variantAttributeLanguage = "language"
+
+	// variantAttributeLength represents the attribute that communicates the
+	// variant size in bytes.
+	variantAttributeLength = "length"
+
+	// variantAttributeFeatures represents the attribute that communicates the
+	// feature list of the variant.
+	variantAttributeFeatures = "features"
+
+	// variantAttributeDescription represents the attribute that communicates
+	// a textual description of the variant.
+	variantAttributeDescription = "description"
+
+	// ErrInvalidAlternates is an error that indicates that the Alternates
+	// header is invalid.
+	ErrInvalidAlternates = errors.New("alternates is invalid")
+
+	// sourceQualityRegex matches the source quality of a variant description.
+	sourceQualityRegex = regexp.MustCompile(`^(0(\.\d{0,3})?|1(\.0{0,3})?)$`)
+
+	// languageTagRegex matches a language tag, as defined in RFC 1766.
+	languageTagRegex = regexp.MustCompile(`^[A-Za-z]{1,8}(-[A-Za-z0-9]{1,8})*$`)
+
+	// lengthRegex matches the length of a variant.
+	lengthRegex = regexp.MustCompile(`^\d+$`)
+)
+
+// variantFallback represents the URL of the variant that user agents should
+// use when it finds all variants listed in the variant description as unacceptable.
+type variantFallback url.URL
+
+// String provides the textual representation of the variant fallback.
+func (f variantFallback) String() string {
+	u := url.URL(f)
+	return fmt.Sprintf("{%s}", quote(u.String()))
+}
+
+// listDirective represents a directive within the Alternates header that
+// applies to the variant list as a whole, such as 'proxy-rvsa'.
+type listDirective struct {
+	name  string
+	value string
+}
+
+// String provides the textual representation of the list directive.
+func (d listDirective) String() string {
+	if len(d.value) == 0 {
+		return d.name
+	}
+	if tokenRegex.MatchString(d.value) {
+		return d.name + "=" + d.value
+	}
+	return d.name + "=" + quote(d.value)
+}
+
+// Alternates represents the Alternates header.
+type Alternates struct {
+	descriptions []VariantDescription
+	fallback     *variantFallback
+	directives   []listDirective
+}
+
+// NewAlternates constructs an Alternates header with the provided representations.
+func NewAlternates(
+	fb representation.Representation, reps ...representation.Representation,
+) (Alternates, error) {
+	var descriptions []VariantDescription
+	for _, rep := range reps {
+		length, known, err := representation.KnownContentLength(rep)
+		if err != nil {
+			return Alternates{}, err
+		}
+		text, language := representation.ContentDescription(rep)
+		attributes := variantAttributes{
+			variantAttributeType:        rep.ContentType(),
+			variantAttributeCharset:     rep.ContentCharset(),
+			variantAttributeLanguage:    rep.ContentLanguage(),
+			variantAttributeFeatures:    strings.Join(rep.ContentFeatures(), " "),
+			variantAttributeDescription: variantText{text: text, language: language},
+		}
+		// the length is optional, and omitted rather than serializing
+		// representations whose length is unknown.
+		if known {
+			attributes[variantAttributeLength] = length
+		}
+		for name, value := range representation.ExtensionAttributes(rep) {
+			if !tokenRegex.MatchString(name) || isVariantAttribute(strings.ToLower(name)) {
+				return Alternates{}, fmt.Errorf("%w: invalid extension attribute %q", ErrInvalidAlternates, name)
+			}
+			attributes[strings.ToLower(name)] = value
+		}
+		descriptions = append(descriptions, VariantDescription{
+			uri:           rep.ContentLocation(),
+			sourceQuality: rep.SourceQuality(),
+			attributes:    attributes,
+		})
+	}
+	var fallback *variantFallback
+	if fb != nil {
+		vf := variantFallback(fb.ContentLocation())
+		fallback = &vf
+	}
+	return Alternates{descriptions: descriptions, fallback: fallback}, nil
+}
+
+// ParseAlternates parses the provided values of the Alternates header, as
+// defined in RFC 2295.
+//
+// https://tools.ietf.org/html/rfc2295#section-8.3
+func ParseAlternates(values []string) (Alternates, error) {
+	var a Alternates
+	for _, line := range values {
+		p := alternatesParser{line: line}
+		if err := p.parse(&a); err != nil {
+			return Alternates{}, err
+		}
+	}
+	if len(a.descriptions) == 0 && a.fallback == nil && len(a.directives) == 0 {
+		line := strings.Join(values, ",")
+		return Alternates{}, invalid(headerAlternates, line, 0, fmt.Errorf("%w: variant list cannot be empty", ErrInvalidAlternates))
+	}
+	return a, nil
+}
+
+// Variants retrieves the variant descriptions.
+func (a Alternates) Variants() []VariantDescription {
+	return append([]VariantDescription{}, a.descriptions...)
+}
+
+// Fallback retrieves the URL of the fallback variant, along with an
+// indication of whether one has been specified.
+func (a Alternates) Fallback() (url.URL, bool) {
+	if a.fallback == nil {
+		return url.URL{}, false
+	}
+	return url.URL(*a.fallback), true
+}
+
+// Directive retrieves the value of the list directive with the provided name,
+// such as 'proxy-rvsa', along with an indication of whether it is present.
+func (a Alternates) Directive(name string) (string, bool) {
+	for _, d := range a.directives {
+		if strings.EqualFold(d.name, name) {
+			return d.value, true
+		}
+	}
+	return "", false
+}
+
+// VariantListValidator provides an opaque validator for the variant
+// descriptions, which changes whenever any of the variant descriptions change.
+//
+// https://tools.ietf.org/html/rfc2295#section-9.1
+func (a Alternates) VariantListValidator() string {
+	h := fnv.New64a()
+	for _, d := range a.descriptions {
+		h.Write([]byte(d.String()))
+		h.Write([]byte{','})
+	}
+	return strconv.FormatUint(h.Sum64(), 16)
+}
+
+// HasFallback indicates if a fallback variant has been specified.
+func (a Alternates) HasFallback() bool {
+	return a.fallback != nil
+}
+
+// String provides the textual representation of the Alternates header value.
+func (a Alternates) String() string {
+	return fmt.Sprintf("%s: %s", headerAlternates, a.ValuesAsString())
+}
+
+// ValuesAsStrings provides the string representation for each value of
+// for the Alternates header.
+func (a Alternates) ValuesAsStrings() []string {
+	s := []string{}
+	for _, d := range a.descriptions {
+		s = append(s, d.String())
+	}
+	if a.HasFallback() {
+		s = append(s, a.fallback.String())
+	}
+	for _, d := range a.directives {
+		s = append(s, d.String())
+	}
+	return s
+}
+
+// ValuesAsString provides a single string containing all of the values for// the Alternates header.
+func (a Alternates) ValuesAsString() string {
+	return strings.Join(a.ValuesAsStrings(), ",")
+}
+
+// alternatesParser parses a single line of the Alternates header.
+type alternatesParser struct {
+	line string
+	pos  int
+}
+
+// fail constructs an error describing the malformed Alternates header at the
+// provided offset.
+func (p *alternatesParser) fail(offset int, format string, args ...interface{}) error {
+	cause := fmt.Errorf("%w: "+format, append([]interface{}{ErrInvalidAlternates}, args...)...)
+	return invalid(headerAlternates, p.line, offset, cause)
+}
+
+// done indicates if the entire line has been consumed.
+func (p *alternatesParser) done() bool {
+	return p.pos >= len(p.line)
+}
+
+// peek provides the next character without consuming it.
+func (p *alternatesParser) peek() byte {
+	if p.done() {
+		return 0
+	}
+	return p.line[p.pos]
+}
+
+// skipLWS consumes any linear white space.
+func (p *alternatesParser) skipLWS() {
+	for !p.done() && (p.peek() == ' ' || p.peek() == '\t') {
+		p.pos++
+	}
+}
+
+// token consumes a token.
+func (p *alternatesParser) token() string {
+	start := p.pos
+	for !p.done() && tokenRegex.MatchString(p.line[p.pos:p.pos+1]) {
+		p.pos++
+	}
+	return p.line[start:p.pos]
+}
+
+// quotedString consumes a quoted string, providing it without the quotes and
+// escapes.
+func (p *alternatesParser) quotedString() (string, error) {
+	start := p.pos
+	if p.peek() != '"' {
+		return "", p.fail(start, "expected quoted string")
+	}
+	p.pos++
+	var b strings.Builder
+	for !p.done() {
+		c := p.line[p.pos]
+		p.pos++
+		switch {
+		case c == '"':
+			return b.String(), nil
+		case c == '\\' && !p.done():
+			b.WriteByte(p.line[p.pos])
+			p.pos++
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return "", p.fail(start, "unterminated quoted string")
+}
+
+// parse consumes the line, adding the elements to the provided header.
+func (p *alternatesParser) parse(a *Alternates) error {
+	for {
+		p.skipLWS()
+		if p.done() {
+			return nil
+		}
+		var err error
+		switch p.peek() {
+		case ',':
+			p.pos++
+			continue
+		case '{':
+			err = p.variant(a)
+		default:
+			err = p.directive(a)
+		}
+		if err != nil {
+			return err
+		}
+		p.skipLWS()
+		if !p.done() && p.peek() != ',' {
+			return p.fail(p.pos, "expected ','")
+		}
+	}
+}
+
+// directive consumes a list directive.
+func (p *alternatesParser) directive(a *Alternates) error {
+	start := p.pos
+	name := p.token()
+	if len(name) == 0 {
+		return p.fail(start, "expected variant description or list directive")
+	}
+	d := listDirective{name: strings.ToLower(name)}
+	p.skipLWS()
+	if p.peek() == '=' {
+		p.pos++
+		p.skipLWS()
+		if p.peek() == '"' {
+			value, err := p.quotedString()
+			if err != nil {
+				return err
+			}
+			d.value = value
+		} else if d.value = p.token(); len(d.value) == 0 {
+			return p.fail(p.pos, "expected list directive value")
+		}
+	}
+	a.directives = append(a.directives, d)
+	return nil
+}
+
+// variant consumes either a variant description or the fallback variant.
+func (p *alternatesParser) variant(a *Alternates) error {
+	start := p.pos
+	p.pos++
+	p.skipLWS()
+	offset := p.pos
+	raw, err := p.quotedString()
+	if err != nil {
+		return err
+	}
+	uri, err := url.Parse(raw)
+	if err != nil {
+		return p.fail(offset, "invalid URI %q", raw)
+	}
+	p.skipLWS()
+
+	// fallback variant.
+	if p.peek() == '}' {
+		p.pos++
+		if a.fallback != nil {
+			return p.fail(start, "multiple fallback variants")
+		}
+		fallback := variantFallback(*uri)
+		a.fallback = &fallback
+		return nil
+	}
+
+	// variant description.
+	offset = p.pos
+	for !p.done() && p.peek() != ' ' && p.peek() != '\t' && p.peek() != '{' && p.peek() != '}' {
+		p.pos++
+	}
+	q := p.line[offset:p.pos]
+	if !sourceQualityRegex.MatchString(q) {
+		return p.fail(offset, "invalid source quality %q", q)
+	}
+	sourceQuality, _ := strconv.ParseFloat(q, 32)
+	d := VariantDescription{
+		uri:           *uri,
+		sourceQuality: float32(sourceQuality),
+		attributes:    variantAttributes{},
+	}
+	for {
+		p.skipLWS()
+		switch p.peek() {
+		case '}':
+			p.pos++
+			a.descriptions = append(a.descriptions, d)
+			return nil
+		case '{':
+			if err := p.attribute(d.attributes); err != nil {
+				return err
+			}
+		case 0:
+			return p.fail(start, "unterminated variant description")
+		default:
+			return p.fail(p.pos, "expected variant attribute")
+		}
+	}
+}
+
+// attribute consumes a variant attribute, adding it to the provided
+// attributes.
+func (p *alternatesParser) attribute(attributes variantAttributes) error {
+	start := p.pos
+	p.pos++
+	p.skipLWS()
+	name := strings.ToLower(p.token())
+	if len(name) == 0 {
+		return p.fail(p.pos, "expected variant attribute name")
+	}
+	if _, ok := attributes[name]; ok {
+		return p.fail(start, "duplicate variant attribute %q", name)
+	}
+	p.skipLWS()
+
+	// the value extends to the closing brace, which may be quoted.
+	offset := p.pos
+	for p.peek() != '}' {
+		if p.done() {
+			return p.fail(start, "unterminated variant attribute %q", name)
+		}
+		if p.peek() == '"' {
+			if _, err := p.quotedString(); err != nil {
+				return err
+			}
+			continue
+		}
+		p.pos++
+	}
+	value := strings.TrimSpace(p.line[offset:p.pos])
+	p.pos++
+
+	switch name {
+	case variantAttributeType:
+		mediaType, _, err := mime.ParseMediaType(value)
+		if err != nil || !strings.Contains(mediaType, "/") {
+			return p.fail(offset, "invalid type %q", value)
+		}
+		attributes[name] = value
+	case variantAttributeCharset:
+		if !tokenRegex.MatchString(value) {
+			return p.fail(offset, "invalid charset %q", value)
+		}
+		attributes[name] = value
+	case variantAttributeLanguage:
+		var tags []string
+		for _, tag := range strings.Split(value, ",") {
+			if tag = strings.TrimSpace(tag); len(tag) == 0 {
+				continue
+			}
+			if !languageTagRegex.MatchString(tag) {
+				return p.fail(offset, "invalid language tag %q", tag)
+			}
+			tags = append(tags, tag)
+		}
+		if len(tags) == 0 {
+			return p.fail(offset, "language cannot be empty")
+		}
+		attributes[name] = strings.Join(tags, ",")
+	case variantAttributeLength:
+		if !lengthRegex.MatchString(value) {
+			return p.fail(offset, "invalid length %q", value)
+		}
+		length, err := strconv.Atoi(value)
+		if err != nil {
+			return p.fail(offset, "invalid length %q", value)
+		}
+		attributes[name] = length
+	case variantAttributeFeatures:
+		if _, err := NewFeatureList(strings.Fields(value)); err != nil {
+			return p.fail(offset, "invalid features %q", value)
+		}
+		attributes[name] = value
+	case variantAttributeDescription:
+		d := alternatesParser{line: value}
+		text, err := d.quotedString()
+		if err != nil {
+			return p.fail(offset, "invalid description %q", value)
+		}
+		tag := strings.TrimSpace(value[d.pos:])
+		if len(tag) > 0 && !languageTagRegex.MatchString(tag) {
+			return p.fail(offset, "invalid description language tag %q", tag)
+		}
+		attributes[name] = variantText{text: text, language: tag}
+	default:
+		attributes[name] = value
+	}
+	return nil
+}
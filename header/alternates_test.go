@@ -0,0 +1,504 @@
+/* Copyright 2020 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package header_test
+
+import (
+	"errors"
+	"net/url"
+	"testing"
+
+	"github.com/freerware/negotiator/header"
+	_representation "github.com/freerware/negotiator/internal/representation"
+	"github.com/freerware/negotiator/internal/test"
+	"github.com/freerware/negotiator/representation"
+	"github.com/stretchr/testify/suite"
+)
+
+type AlternatesTestSuite struct {
+	suite.Suite
+}
+
+func TestAlternatesTestSuite(t *testing.T) {
+	suite.Run(t, new(AlternatesTestSuite))
+}
+
+func (s AlternatesTestSuite) TestAlternates_NewAlternates() {
+	json, html, english, ascii, gzip := "application/json", "text/html", "en-US", "ascii", "gzip"
+	loc, _ := url.Parse("http://www.example.com/thing")
+	v1 := _representation.NewBuilder().
+		WithLocation(*loc).
+		WithType(json).
+		WithLanguage(english).
+		WithEncoding(gzip).
+		WithCharset(ascii).
+		WithSourceQuality(1.0).
+		Build(test.RepresentationBuilderFunc)
+	v2 := _representation.NewBuilder().
+		WithLocation(*loc).
+		WithType(html).
+		WithLanguage(english).
+		WithEncoding(gzip).
+		WithCharset(ascii).
+		WithSourceQuality(1.0).
+		Build(test.RepresentationBuilderFunc)
+
+	tests := []struct {
+		name     string
+		fallback representation.Representation
+		reps     []representation.Representation
+		err      error
+	}{
+		{"WithFallback", v1, []representation.Representation{v2}, nil},
+		{"WithoutFallback", nil, []representation.Representation{v2}, nil},
+		{"Empty", nil, []representation.Representation{}, nil},
+	}
+
+	for _, test := range tests {
+		s.Run(test.name, func() {
+			// action.
+			_, err := header.NewAlternates(test.fallback, test.reps...)
+
+			// assert.
+			if test.err != nil {
+				s.Require().EqualError(err, test.err.Error())
+			} else {
+				s.Require().NoError(err)
+			}
+		})
+	}
+}
+
+func (s AlternatesTestSuite) TestAlternates_HasFallback() {
+	json, english, ascii, gzip := "application/json", "en-US", "ascii", "gzip"
+	loc, _ := url.Parse("http://www.example.com/thing")
+	v1 := _representation.NewBuilder().
+		WithLocation(*loc).
+		WithType(json).
+		WithLanguage(english).
+		WithEncoding(gzip).
+		WithCharset(ascii).
+		WithSourceQuality(1.0).
+		Build(test.RepresentationBuilderFunc)
+
+	tests := []struct {
+		name     string
+		fallback representation.Representation
+		out      bool
+	}{
+		{"HasFallback", v1, true},
+		{"NoFallback", nil, false},
+	}
+
+	for _, test := range tests {
+		s.Run(test.name, func() {
+			// action + assert.
+			a, err := header.NewAlternates(test.fallback)
+			s.Require().NoError(err)
+			s.Equal(test.out, a.HasFallback())
+		})
+	}
+}
+
+func (s AlternatesTestSuite) TestAlternates_ValuesAsStrings() {
+	json, html, english, ascii, gzip := "application/json", "text/html", "en-US", "ascii", "gzip"
+	loc, _ := url.Parse("http://www.example.com/thing")
+	v1 := _representation.NewBuilder().
+		WithLocation(*loc).
+		WithType(json).
+		WithLanguage(english).
+		WithEncoding(gzip).
+		WithCharset(ascii).
+		WithSourceQuality(1.0).
+		Build(test.RepresentationBuilderFunc)
+	v2 := _representation.NewBuilder().
+		WithLocation(*loc).
+		WithType(html).
+		WithLanguage(english).
+		WithEncoding(gzip).
+		WithCharset(ascii).
+		WithSourceQuality(1.0).
+		Build(test.RepresentationBuilderFunc)
+
+	tests := []struct {
+		name     string
+		fallback representation.Representation
+		reps     []representation.Representation
+		out      []string
+	}{
+		{
+			"WithFallback",
+			v1,
+			[]representation.Representation{v2},
+			[]string{"{\"http://www.example.com/thing\" 1.000 {type text/html} {charset ascii} {language en-US} {length 59}}", "{\"http://www.example.com/thing\"}"},
+		},
+		{
+			"WithoutFallback",
+			nil,
+			[]representation.Representation{v2},
+			[]string{"{\"http://www.example.com/thing\" 1.000 {type text/html} {charset ascii} {language en-US} {length 59}}"},
+		},
+		{
+			"Empty",
+			nil,
+			[]representation.Representation{},
+			[]string{},
+		},
+	}
+
+	for _, test := range tests {
+		s.Run(test.name, func() {
+			// action + assert.
+			a, err := header.NewAlternates(test.fallback, test.reps...)
+			s.Require().NoError(err)
+			s.Equal(test.out, a.ValuesAsStrings())
+		})
+	}
+}
+
+func (s AlternatesTestSuite) TestAlternates_ValuesAsString() {
+	json, html, english, ascii, gzip := "application/json", "text/html", "en-US", "ascii", "gzip"
+	loc, _ := url.Parse("http://www.example.com/thing")
+	v1 := _representation.NewBuilder().
+		WithLocation(*loc).
+		WithType(json).
+		WithLanguage(english).
+		WithEncoding(gzip).
+		WithCharset(ascii).
+		WithSourceQuality(1.0).
+		Build(test.RepresentationBuilderFunc)
+	v2 := _representation.NewBuilder().
+		WithLocation(*loc).
+		WithType(html).
+		WithLanguage(english).
+		WithEncoding(gzip).
+		WithCharset(ascii).
+		WithSourceQuality(1.0).
+		Build(test.RepresentationBuilderFunc)
+
+	tests := []struct {
+		name     string
+		fallback representation.Representation
+		reps     []representation.Representation
+		out      string
+	}{
+		{
+			"WithFallback",
+			v1,
+			[]representation.Representation{v2},
+			"{\"http://www.example.com/thing\" 1.000 {type text/html} {charset ascii} {language en-US} {length 59}},{\"http://www.example.com/thing\"}",
+		},
+		{
+			"WithoutFallback",
+			nil,
+			[]representation.Representation{v2},
+			"{\"http://www.example.com/thing\" 1.000 {type text/html} {charset ascii} {language en-US} {length 59}}",
+		},
+		{
+			"Empty",
+			nil,
+			[]representation.Representation{},
+			"",
+		},
+	}
+
+	for _, test := range tests {
+		s.Run(test.name, func() {
+			// action + assert.
+			a, err := header.NewAlternates(test.fallback, test.reps...)
+			s.Require().NoError(err)
+			s.Equal(test.out, a.ValuesAsString())
+		})
+	}
+}
+
+func (s AlternatesTestSuite) TestAlternates_String() {
+	json, html, english, ascii, gzip := "application/json", "text/html", "en-US", "ascii", "gzip"
+	loc, _ := url.Parse("http://www.example.com/thing")
+	v1 := _representation.NewBuilder().
+		WithLocation(*loc).
+		WithType(json).
+		WithLanguage(english).
+		WithEncoding(gzip).
+		WithCharset(ascii).
+		WithSourceQuality(1.0).
+		Build(test.RepresentationBuilderFunc)
+	v2 := _representation.NewBuilder().
+		WithLocation(*loc).
+		WithType(html).
+		WithLanguage(english).
+		WithEncoding(gzip).
+		WithCharset(ascii).
+		WithSourceQuality(1.0).
+		Build(test.RepresentationBuilderFunc)
+
+	tests := []struct {
+		name     string
+		fallback representation.Representation
+		reps     []representation.Representation
+		out      string
+	}{
+		{
+			"WithFallback",
+			v1,
+			[]representation.Representation{v2},
+			"Alternates: {\"http://www.example.com/thing\" 1.000 {type text/html} {charset ascii} {language en-US} {length 59}},{\"http://www.example.com/thing\"}",
+		},
+		{
+			"WithoutFallback",
+			nil,
+			[]representation.Representation{v2},
+			"Alternates: {\"http://www.example.com/thing\" 1.000 {type text/html} {charset ascii} {language en-US} {length 59}}",
+		},
+		{
+			"Empty",
+			nil,
+			[]representation.Representation{},
+			"Alternates: ",
+		},
+	}
+
+	for _, test := range tests {
+		s.Run(test.name, func() {
+			// action + assert.
+			a, err := header.NewAlternates(test.fallback, test.reps...)
+			s.Require().NoError(err)
+			s.Equal(test.out, a.String())
+		})
+	}
+}
+
+func (s AlternatesTestSuite) TestAlternates_ContentLengthHint() {
+	// arrange.
+	loc, _ := url.Parse("http://www.example.com/thing")
+	invocations := 0
+	v := representation.NewLazy(func() (string, error) {
+		invocations++
+		return "thing", nil
+	})
+	v.SetContentLocation(*loc)
+	v.SetContentType("application/json")
+	v.SetSourceQuality(1.0)
+	v.SetContentLength(42)
+
+	// action.
+	a, err := header.NewAlternates(nil, v)
+
+	// assert.
+	s.Require().NoError(err)
+	s.Contains(a.ValuesAsString(), "{length 42}")
+	s.Zero(invocations)
+}
+
+func (s AlternatesTestSuite) TestAlternates_UnknownContentLength() {
+	// arrange.
+	loc, _ := url.Parse("http://www.example.com/thing")
+	invocations := 0
+	v := representation.NewLazy(func() (string, error) {
+		invocations++
+		return "thing", nil
+	})
+	v.SetContentLocation(*loc)
+	v.SetContentType("application/json")
+	v.SetSourceQuality(1.0)
+
+	// action.
+	a, err := header.NewAlternates(nil, v)
+
+	// assert.
+	s.Require().NoError(err)
+	s.NotContains(a.ValuesAsString(), "{length")
+	_, known := a.Variants()[0].Length()
+	s.False(known)
+	s.Zero(invocations)
+}
+
+func (s AlternatesTestSuite) TestAlternates_ParseAlternates() {
+	// arrange.
+	values := []string{
+		`{"paper.1" 0.9 {type text/html} {language en}}, {"paper.2" 0.7 {type text/html} {language fr}}`,
+		`{"paper.3" 1.0 {type application/postscript} {language en}}, proxy-rvsa="1.0, 2.5"`,
+	}
+
+	// action.
+	a, err := header.ParseAlternates(values)
+
+	// assert.
+	s.Require().NoError(err)
+	variants := a.Variants()
+	s.Require().Len(variants, 3)
+	for i, expected := range []struct {
+		uri      string
+		quality  float32
+		typ      string
+		language string
+	}{
+		{"paper.1", 0.9, "text/html", "en"},
+		{"paper.2", 0.7, "text/html", "fr"},
+		{"paper.3", 1.0, "application/postscript", "en"},
+	} {
+		uri := variants[i].URI()
+		s.Equal(expected.uri, uri.String())
+		s.Equal(expected.quality, variants[i].SourceQuality())
+		s.Equal(expected.typ, variants[i].Type())
+		s.Equal([]string{expected.language}, variants[i].Languages())
+	}
+	s.False(a.HasFallback())
+	rvsa, ok := a.Directive("proxy-rvsa")
+	s.True(ok)
+	s.Equal("1.0, 2.5", rvsa)
+}
+
+func (s AlternatesTestSuite) TestAlternates_ParseAlternates_Attributes() {
+	// arrange.
+	value := `{"http://www.example.com/thing.html.en" 0.8 {type text/html;level=2} {charset iso-8859-1}` +
+		` {language en, en-GB} {length 4120} {features tables !frames} {description "The \"thing\"" en}` +
+		` {x-checksum "a}b" sha}}, {"http://www.example.com/thing.txt"}, x-directive`
+
+	// action.
+	a, err := header.ParseAlternates([]string{value})
+
+	// assert.
+	s.Require().NoError(err)
+	variants := a.Variants()
+	s.Require().Len(variants, 1)
+	v := variants[0]
+	s.Equal("text/html;level=2", v.Type())
+	s.Equal("iso-8859-1", v.Charset())
+	s.Equal([]string{"en", "en-GB"}, v.Languages())
+	length, ok := v.Length()
+	s.True(ok)
+	s.Equal(4120, length)
+	s.Equal([]string{"tables", "!frames"}, v.Features())
+	text, tag := v.Description()
+	s.Equal(`The "thing"`, text)
+	s.Equal("en", tag)
+	s.Equal(map[string]string{"x-checksum": `"a}b" sha`}, v.Extensions())
+	fallback, ok := a.Fallback()
+	s.True(ok)
+	s.Equal("http://www.example.com/thing.txt", fallback.String())
+	_, ok = a.Directive("x-directive")
+	s.True(ok)
+}
+
+func (s AlternatesTestSuite) TestAlternates_ParseAlternates_RoundTrip() {
+	// arrange.
+	loc, _ := url.Parse("http://www.example.com/thing")
+	v := _representation.NewBuilder().
+		WithLocation(*loc).
+		WithType("text/html").
+		WithLanguage("en-US").
+		WithCharset("ascii").
+		WithFeature("tables").
+		WithSourceQuality(0.5).
+		Build(test.RepresentationBuilderFunc)
+	expected, err := header.NewAlternates(v, v)
+	s.Require().NoError(err)
+
+	// action.
+	a, err := header.ParseAlternates(expected.ValuesAsStrings())
+
+	// assert.
+	s.Require().NoError(err)
+	s.Equal(expected.ValuesAsString(), a.ValuesAsString())
+}
+
+func (s AlternatesTestSuite) TestAlternates_ParseAlternates_Invalid() {
+	tests := []struct {
+		name   string
+		value  string
+		offset int
+	}{
+		{"Empty", "", 0},
+		{"UnquotedURI", "{paper.1 0.9}", 1},
+		{"UnterminatedURI", `{"paper.1 0.9}`, 1},
+		{"SourceQuality", `{"paper.1" 1.5}`, 11},
+		{"MissingSourceQuality", `{"paper.1" {type text/html}}`, 11},
+		{"Type", `{"paper.1" 0.9 {type text}}`, 21},
+		{"Charset", `{"paper.1" 0.9 {charset a b}}`, 24},
+		{"Language", `{"paper.1" 0.9 {language en_US}}`, 25},
+		{"Length", `{"paper.1" 0.9 {length -1}}`, 23},
+		{"Features", `{"paper.1" 0.9 {features [}}`, 25},
+		{"Description", `{"paper.1" 0.9 {description thing}}`, 28},
+		{"DuplicateAttribute", `{"paper.1" 0.9 {type text/html} {type text/plain}}`, 32},
+		{"UnterminatedAttribute", `{"paper.1" 0.9 {type text/html`, 15},
+		{"UnterminatedDescription", `{"paper.1" 0.9`, 0},
+		{"UnexpectedAttribute", `{"paper.1" 0.9 type}`, 15},
+		{"MultipleFallbacks", `{"paper.1"}, {"paper.2"}`, 13},
+		{"MissingSeparator", `{"paper.1"} {"paper.2"}`, 12},
+		{"Directive", `proxy-rvsa=`, 11},
+	}
+	for _, test := range tests {
+		s.Run(test.name, func() {
+			// action.
+			_, err := header.ParseAlternates([]string{test.value})
+
+			// assert.
+			s.Require().ErrorIs(err, header.ErrInvalidAlternates)
+			var headerErr *header.Error
+			s.Require().True(errors.As(err, &headerErr))
+			s.Equal("Alternates", headerErr.Header)
+			s.Equal(test.value, headerErr.Value)
+			s.Equal(test.offset, headerErr.Offset)
+		})
+	}
+}
+
+func (s AlternatesTestSuite) TestAlternates_NewAlternates_Grammar() {
+	// arrange.
+	loc, _ := url.Parse("http://www.example.com/paper.1")
+	v := representation.NewLazy(func() (string, error) { return "thing", nil })
+	v.SetContentLocation(*loc)
+	v.SetContentType(`text/plain; format="a b"; charset=utf-8`)
+	v.SetContentLanguage("en, fr")
+	v.SetContentFeatures([]string{"tables", "!frames"})
+	v.SetContentDescription(`The "paper"`, "en")
+	v.SetExtensionAttribute("x-checksum", "sha256")
+	v.SetExtensionAttribute("x-note", "a}b")
+	v.SetSourceQuality(0.9)
+	v.SetContentLength(42)
+	empty := representation.NewLazy(func() (string, error) { return "", nil })
+	empty.SetContentLocation(*loc)
+	empty.SetSourceQuality(0.12345)
+	empty.SetContentLength(0)
+
+	// action.
+	a, err := header.NewAlternates(nil, v, empty)
+
+	// assert.
+	s.Require().NoError(err)
+	s.Equal([]string{
+		`{"http://www.example.com/paper.1" 0.900 {type text/plain;charset=utf-8;format="a b"} {language en,fr}` +
+			` {length 42} {features tables !frames} {description "The \"paper\"" en} {x-checksum sha256} {x-note "a}b"}}`,
+		`{"http://www.example.com/paper.1" 0.123 {length 0}}`,
+	}, a.ValuesAsStrings())
+	parsed, err := header.ParseAlternates(a.ValuesAsStrings())
+	s.Require().NoError(err)
+	s.Equal(a.ValuesAsString(), parsed.ValuesAsString())
+}
+
+func (s AlternatesTestSuite) TestAlternates_NewAlternates_InvalidExtension() {
+	// arrange.
+	v := representation.NewLazy(func() (string, error) { return "thing", nil })
+	v.SetContentType("text/plain")
+	v.SetContentLength(5)
+	v.SetExtensionAttribute("type", "text/html")
+
+	// action.
+	_, err := header.NewAlternates(nil, v)
+
+	// assert.
+	s.ErrorIs(err, header.ErrInvalidAlternates)
+}
@@ -0,0 +1,104 @@
+/* Copyright 2020 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package header
+
+import (
+	"strconv"
+	"strings"
+)
+
+// WeightedBuilder builds the value of a header consisting of a list of
+// elements that are each assigned a quality value, such as the Accept header.
+type WeightedBuilder[T any] struct {
+	elements []string
+	parse    func([]string) (T, error)
+}
+
+// AcceptBuilder constructs a builder for the Accept header.
+func AcceptBuilder() WeightedBuilder[Accept] {
+	return WeightedBuilder[Accept]{parse: NewAccept}
+}
+
+// AcceptCharsetBuilder constructs a builder for the Accept-Charset header.
+func AcceptCharsetBuilder() WeightedBuilder[AcceptCharset] {
+	return WeightedBuilder[AcceptCharset]{parse: NewAcceptCharset}
+}
+
+// AcceptEncodingBuilder constructs a builder for the Accept-Encoding header.
+func AcceptEncodingBuilder() WeightedBuilder[AcceptEncoding] {
+	return WeightedBuilder[AcceptEncoding]{parse: NewAcceptEncoding}
+}
+
+// AcceptLanguageBuilder constructs a builder for the Accept-Language header.
+func AcceptLanguageBuilder() WeightedBuilder[AcceptLanguage] {
+	return WeightedBuilder[AcceptLanguage]{parse: NewAcceptLanguage}
+}
+
+// Add introduces the provided element, such as a media range, with the
+// provided quality value. The quality value is omitted from the header value
+// when it is the maximum quality value.
+func (b WeightedBuilder[T]) Add(element string, qvalue float32) WeightedBuilder[T] {
+	if q := QualityValue(qvalue); !q.Equals(QualityValueMaximum) {
+		element = element + ";q=" + strconv.FormatFloat(float64(q.Round(3)), 'f', -1, 32)
+	}
+	b.elements = append(b.elements[:len(b.elements):len(b.elements)], element)
+	return b
+}
+
+// String provides the header value.
+func (b WeightedBuilder[T]) String() string {
+	return strings.Join(b.elements, ", ")
+}
+
+// Build parses the header value, which fails when any of the elements or
+// quality values provided are invalid.
+func (b WeightedBuilder[T]) Build() (T, error) {
+	return b.parse([]string{b.String()})
+}
+
+// ListBuilder builds the value of a header consisting of a list of elements,
+// such as the Negotiate header.
+type ListBuilder[T any] struct {
+	elements []string
+	parse    func([]string) (T, error)
+}
+
+// AcceptFeaturesBuilder constructs a builder for the Accept-Features header.
+func AcceptFeaturesBuilder() ListBuilder[AcceptFeatures] {
+	return ListBuilder[AcceptFeatures]{parse: NewAcceptFeatures}
+}
+
+// NegotiateBuilder constructs a builder for the Negotiate header.
+func NegotiateBuilder() ListBuilder[Negotiate] {
+	return ListBuilder[Negotiate]{parse: NewNegotiate}
+}
+
+// Add introduces the provided elements, such as negotiate directives.
+func (b ListBuilder[T]) Add(elements ...string) ListBuilder[T] {
+	b.elements = append(b.elements[:len(b.elements):len(b.elements)], elements...)
+	return b
+}
+
+// String provides the header value.
+func (b ListBuilder[T]) String() string {
+	return strings.Join(b.elements, ", ")
+}
+
+// Build parses the header value, which fails when any of the elements
+// provided are invalid.
+func (b ListBuilder[T]) Build() (T, error) {
+	return b.parse([]string{b.String()})
+}
@@ -0,0 +1,188 @@
+package header_test
+
+import (
+	"testing"
+
+	"github.com/freerware/negotiator/header"
+	"github.com/stretchr/testify/suite"
+)
+
+type BuilderTestSuite struct {
+	suite.Suite
+}
+
+func TestBuilderTestSuite(t *testing.T) {
+	suite.Run(t, new(BuilderTestSuite))
+}
+
+func (s BuilderTestSuite) TestAcceptBuilder() {
+	// arrange.
+	b := header.AcceptBuilder().
+		Add("application/json", 1).
+		Add("*/*", 0.1)
+
+	// action.
+	accept, err := b.Build()
+
+	// assert.
+	s.Equal("application/json, */*;q=0.1", b.String())
+	s.Require().NoError(err)
+	s.Equal("Accept: application/json,*/*;q=0.100", accept.String())
+}
+
+func (s BuilderTestSuite) TestWeightedBuilders() {
+	tests := []struct {
+		name  string
+		build func() (string, error)
+		out   string
+	}{
+		{"Accept", func() (string, error) {
+			b := header.AcceptBuilder().Add("text/html", 1).Add(`text/plain;format="a, b"`, 0.5)
+			_, err := b.Build()
+			return b.String(), err
+		}, `text/html, text/plain;format="a, b";q=0.5`},
+		{"AcceptCharset", func() (string, error) {
+			b := header.AcceptCharsetBuilder().Add("utf-8", 1).Add("iso-8859-1", 0.25)
+			_, err := b.Build()
+			return b.String(), err
+		}, "utf-8, iso-8859-1;q=0.25"},
+		{"AcceptEncoding", func() (string, error) {
+			b := header.AcceptEncodingBuilder().Add("br", 1).Add("gzip", 0.8).Add("identity", 0)
+			_, err := b.Build()
+			return b.String(), err
+		}, "br, gzip;q=0.8, identity;q=0"},
+		{"AcceptLanguage", func() (string, error) {
+			b := header.AcceptLanguageBuilder().Add("en-US", 1).Add("en", 0.9).Add("*", 0.001)
+			_, err := b.Build()
+			return b.String(), err
+		}, "en-US, en;q=0.9, *;q=0.001"},
+		{"Empty", func() (string, error) {
+			b := header.AcceptBuilder()
+			_, err := b.Build()
+			return b.String(), err
+		}, ""},
+	}
+	for _, test := range tests {
+		s.Run(test.name, func() {
+			// action.
+			out, err := test.build()
+
+			// assert.
+			s.Require().NoError(err)
+			s.Equal(test.out, out)
+		})
+	}
+}
+
+func (s BuilderTestSuite) TestListBuilders() {
+	// arrange.
+	negotiate := header.NegotiateBuilder().
+		Add(header.NegotiateDirectiveTrans.String()).
+		Add(header.NegotiateDirectiveVList.String(), "1.0")
+	features := header.AcceptFeaturesBuilder().Add("tables", "!frames")
+
+	// action.
+	n, nerr := negotiate.Build()
+	f, ferr := features.Build()
+
+	// assert.
+	s.Equal("trans, vlist, 1.0", negotiate.String())
+	s.Require().NoError(nerr)
+	s.True(n.Contains(header.NegotiateDirectiveVList))
+	s.True(n.ContainsRVSA("1.0"))
+	s.Equal("tables, !frames", features.String())
+	s.Require().NoError(ferr)
+	s.False(f.IsEmpty())
+}
+
+func (s BuilderTestSuite) TestBuilder_Immutable() {
+	// arrange.
+	base := header.AcceptBuilder().Add("application/json", 1)
+
+	// action.
+	xml := base.Add("application/xml", 0.5)
+	html := base.Add("text/html", 0.5)
+
+	// assert.
+	s.Equal("application/json", base.String())
+	s.Equal("application/json, application/xml;q=0.5", xml.String())
+	s.Equal("application/json, text/html;q=0.5", html.String())
+}
+
+func (s BuilderTestSuite) TestBuilder_Invalid() {
+	tests := []struct {
+		name  string
+		build func() error
+		err   error
+	}{
+		{"QualityValue", func() error {
+			_, err := header.AcceptBuilder().Add("application/json", 2).Build()
+			return err
+		}, header.ErrInvalidQualityValue},
+		{"Directive", func() error {
+			_, err := header.NegotiateBuilder().Add("vlist;x").Build()
+			return err
+		}, header.ErrInvalidNegotiateDirective},
+	}
+	for _, test := range tests {
+		s.Run(test.name, func() {
+			// action.
+			err := test.build()
+
+			// assert.
+			s.ErrorIs(err, test.err)
+		})
+	}
+}
+
+func (s BuilderTestSuite) TestRoundTrip() {
+	tests := []struct {
+		name  string
+		value string
+		parse func(string) (interface{}, string, error)
+	}{
+		{"Accept", "text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8,text/plain;format=\"a\\\"b\";q=0.1", func(v string) (interface{}, string, error) {
+			h, err := header.NewAccept([]string{v})
+			return h, h.ValuesAsString(), err
+		}},
+		{"AcceptCharset", "ISO-8859-1,utf-8;q=0.7,*;q=0.7", func(v string) (interface{}, string, error) {
+			h, err := header.NewAcceptCharset([]string{v})
+			return h, h.ValuesAsString(), err
+		}},
+		{"AcceptEncoding", "gzip, deflate, br, zstd, identity;q=0", func(v string) (interface{}, string, error) {
+			h, err := header.NewAcceptEncoding([]string{v})
+			return h, h.ValuesAsString(), err
+		}},
+		{"AcceptLanguage", "en-US,en;q=0.5,*;q=0.1", func(v string) (interface{}, string, error) {
+			h, err := header.NewAcceptLanguage([]string{v})
+			return h, h.ValuesAsString(), err
+		}},
+		{"AcceptFeatures", "tables, !frames, screenwidth=[-800]", func(v string) (interface{}, string, error) {
+			h, err := header.NewAcceptFeatures([]string{v})
+			return h, h.ValuesAsString(), err
+		}},
+		{"Negotiate", "trans, vlist, 1.0", func(v string) (interface{}, string, error) {
+			h, err := header.NewNegotiate([]string{v})
+			return h, h.ValuesAsString(), err
+		}},
+		{"TCN", "choice, keep", func(v string) (interface{}, string, error) {
+			h, err := header.NewTCN([]string{v})
+			return h, h.ValuesAsString(), err
+		}},
+	}
+	for _, test := range tests {
+		s.Run(test.name, func() {
+			// arrange.
+			parsed, formatted, err := test.parse(test.value)
+			s.Require().NoError(err)
+
+			// action.
+			reparsed, reformatted, err := test.parse(formatted)
+
+			// assert.
+			s.Require().NoError(err)
+			s.Equal(parsed, reparsed)
+			s.Equal(formatted, reformatted)
+		})
+	}
+}
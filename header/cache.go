@@ -0,0 +1,201 @@
+/* Copyright 2020 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package header
+
+import (
+	"container/list"
+	"context"
+	"strings"
+	"sync"
+
+	"github.com/uber-go/tally"
+)
+
+// Defines the scope names for header cache metrics.
+var (
+	scopeNameCacheHitCounter      = "negotiate.header_cache.hit"
+	scopeNameCacheMissCounter     = "negotiate.header_cache.miss"
+	scopeNameCacheEvictionCounter = "negotiate.header_cache.eviction"
+	scopeNameCacheHitRatioGauge   = "negotiate.header_cache.hit_ratio"
+)
+
+// cacheContextKey is the key of the header cache within a request context.
+type cacheContextKey struct{}
+
+// cacheKey identifies a parsed header value within the header cache.
+type cacheKey struct {
+	name  string
+	lines string
+}
+
+// cacheEntry is a parsed header value within the header cache.
+type cacheEntry struct {
+	key    cacheKey
+	parsed interface{}
+}
+
+// Cache is a bounded cache of parsed request header values, keyed on the raw
+// header field lines, that evicts the least recently used value once full.
+// Since parsed headers are immutable, a cache can be shared by any number of
+// goroutines. A nil cache parses header values on every use.
+type Cache struct {
+	mu       sync.Mutex
+	capacity int
+	entries  map[cacheKey]*list.Element
+	order    *list.List
+	hits     int64
+	misses   int64
+	scope    tally.Scope
+}
+
+// NewCache constructs a header cache holding at most the provided number of
+// parsed header values, reporting its effectiveness to the provided scope.
+func NewCache(capacity int, scope tally.Scope) *Cache {
+	if scope == nil {
+		scope = tally.NoopScope
+	}
+	return &Cache{
+		capacity: capacity,
+		entries:  make(map[cacheKey]*list.Element),
+		order:    list.New(),
+		scope:    scope,
+	}
+}
+
+// WithCache provides a copy of the provided context carrying the provided
+// header cache.
+func WithCache(ctx context.Context, c *Cache) context.Context {
+	if c == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, cacheContextKey{}, c)
+}
+
+// CacheFrom retrieves the header cache carried by the provided context, which
+// is nil when there is none.
+func CacheFrom(ctx context.Context) *Cache {
+	c, _ := ctx.Value(cacheContextKey{}).(*Cache)
+	return c
+}
+
+// Len provides the number of parsed header values within the cache.
+func (c *Cache) Len() int {
+	if c == nil {
+		return 0
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
+
+// Accept provides the parsed Accept header for the provided lines.
+func (c *Cache) Accept(lines []string) (Accept, error) {
+	return cached(c, headerAccept, lines, NewAccept)
+}
+
+// AcceptCharset provides the parsed Accept-Charset header for the provided
+// lines.
+func (c *Cache) AcceptCharset(lines []string) (AcceptCharset, error) {
+	return cached(c, headerAcceptCharset, lines, NewAcceptCharset)
+}
+
+// AcceptEncoding provides the parsed Accept-Encoding header for the provided
+// lines.
+func (c *Cache) AcceptEncoding(lines []string) (AcceptEncoding, error) {
+	return cached(c, headerAcceptEncoding, lines, NewAcceptEncoding)
+}
+
+// AcceptFeatures provides the parsed Accept-Features header for the provided
+// lines.
+func (c *Cache) AcceptFeatures(lines []string) (AcceptFeatures, error) {
+	return cached(c, headerAcceptFeatures, lines, NewAcceptFeatures)
+}
+
+// AcceptLanguage provides the parsed Accept-Language header for the provided
+// lines.
+func (c *Cache) AcceptLanguage(lines []string) (AcceptLanguage, error) {
+	return cached(c, headerAcceptLanguage, lines, NewAcceptLanguage)
+}
+
+// Negotiate provides the parsed Negotiate header for the provided lines.
+func (c *Cache) Negotiate(lines []string) (Negotiate, error) {
+	return cached(c, headerNegotiate, lines, NewNegotiate)
+}
+
+// cached provides the parsed value of the header with the provided name and
+// lines from the cache, parsing and retaining it when absent. Malformed
+// header values are never retained.
+func cached[T any](
+	c *Cache, name string, lines []string, parse func([]string) (T, error),
+) (T, error) {
+	if c == nil || c.capacity <= 0 {
+		return parse(lines)
+	}
+
+	key := cacheKey{name: name, lines: strings.Join(lines, "\n")}
+	if parsed, ok := c.get(key); ok {
+		return parsed.(T), nil
+	}
+	parsed, err := parse(lines)
+	if err != nil {
+		return parsed, err
+	}
+	c.put(key, parsed)
+	return parsed, nil
+}
+
+// get retrieves the parsed header value with the provided key, marking it as
+// the most recently used.
+func (c *Cache) get(key cacheKey) (interface{}, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.entries[key]
+	if ok {
+		c.hits++
+		c.order.MoveToFront(e)
+		c.scope.Counter(scopeNameCacheHitCounter).Inc(1)
+	} else {
+		c.misses++
+		c.scope.Counter(scopeNameCacheMissCounter).Inc(1)
+	}
+	c.scope.Gauge(scopeNameCacheHitRatioGauge).Update(
+		float64(c.hits) / float64(c.hits+c.misses),
+	)
+	if !ok {
+		return nil, false
+	}
+	return e.Value.(*cacheEntry).parsed, true
+}
+
+// put retains the parsed header value with the provided key, evicting the
+// least recently used value when the cache is full.
+func (c *Cache) put(key cacheKey, parsed interface{}) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if e, ok := c.entries[key]; ok {
+		c.order.MoveToFront(e)
+		return
+	}
+	c.entries[key] = c.order.PushFront(&cacheEntry{key: key, parsed: parsed})
+	for c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*cacheEntry).key)
+		c.scope.Counter(scopeNameCacheEvictionCounter).Inc(1)
+	}
+}
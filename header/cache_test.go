@@ -0,0 +1,147 @@
+package header_test
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/freerware/negotiator/header"
+	"github.com/stretchr/testify/suite"
+	"github.com/uber-go/tally"
+)
+
+type CacheTestSuite struct {
+	suite.Suite
+}
+
+func TestCacheTestSuite(t *testing.T) {
+	suite.Run(t, new(CacheTestSuite))
+}
+
+func (s CacheTestSuite) TestCache_Hit() {
+	// arrange.
+	scope := tally.NewTestScope("", nil)
+	sut := header.NewCache(10, scope)
+	lines := []string{"text/html,application/xhtml+xml", "*/*;q=0.8"}
+
+	// action.
+	first, err := sut.Accept(lines)
+	s.Require().NoError(err)
+	second, err := sut.Accept(lines)
+	s.Require().NoError(err)
+
+	// assert.
+	s.Equal(first, second)
+	s.Equal(1, sut.Len())
+	snapshot := scope.Snapshot()
+	s.Equal(int64(1), snapshot.Counters()["negotiate.header_cache.hit+"].Value())
+	s.Equal(int64(1), snapshot.Counters()["negotiate.header_cache.miss+"].Value())
+	s.Equal(0.5, snapshot.Gauges()["negotiate.header_cache.hit_ratio+"].Value())
+}
+
+func (s CacheTestSuite) TestCache_KeyedOnHeader() {
+	// arrange.
+	sut := header.NewCache(10, nil)
+
+	// action.
+	charset, err := sut.AcceptCharset([]string{"utf-8"})
+	s.Require().NoError(err)
+	encoding, err := sut.AcceptEncoding([]string{"utf-8"})
+	s.Require().NoError(err)
+	_, err = sut.AcceptCharset([]string{"utf-8", "ascii"})
+	s.Require().NoError(err)
+	_, err = sut.AcceptCharset([]string{"utf-8,ascii"})
+	s.Require().NoError(err)
+
+	// assert.
+	s.Equal("Accept-Charset: utf-8;q=1.000", charset.String())
+	s.Equal("Accept-Encoding: utf-8;q=1.000", encoding.String())
+	s.Equal(4, sut.Len())
+}
+
+func (s CacheTestSuite) TestCache_Eviction() {
+	// arrange.
+	scope := tally.NewTestScope("", nil)
+	sut := header.NewCache(2, scope)
+	_, err := sut.AcceptLanguage([]string{"en"})
+	s.Require().NoError(err)
+	_, err = sut.AcceptLanguage([]string{"fr"})
+	s.Require().NoError(err)
+
+	// action.
+	_, err = sut.AcceptLanguage([]string{"en"})
+	s.Require().NoError(err)
+	_, err = sut.AcceptLanguage([]string{"de"})
+	s.Require().NoError(err)
+	_, err = sut.AcceptLanguage([]string{"en"})
+	s.Require().NoError(err)
+	_, err = sut.AcceptLanguage([]string{"fr"})
+	s.Require().NoError(err)
+
+	// assert.
+	s.Equal(2, sut.Len())
+	snapshot := scope.Snapshot()
+	s.Equal(int64(2), snapshot.Counters()["negotiate.header_cache.hit+"].Value())
+	s.Equal(int64(4), snapshot.Counters()["negotiate.header_cache.miss+"].Value())
+	s.Equal(int64(2), snapshot.Counters()["negotiate.header_cache.eviction+"].Value())
+}
+
+func (s CacheTestSuite) TestCache_Invalid() {
+	// arrange.
+	sut := header.NewCache(10, nil)
+
+	// action.
+	_, err := sut.Negotiate([]string{"trans, vlist;x"})
+
+	// assert.
+	s.ErrorIs(err, header.ErrInvalidHeader)
+	s.Zero(sut.Len())
+}
+
+func (s CacheTestSuite) TestCache_Nil() {
+	// arrange.
+	var sut *header.Cache
+
+	// action.
+	af, err := sut.AcceptFeatures([]string{"tables, !frames"})
+
+	// assert.
+	s.Require().NoError(err)
+	s.False(af.IsEmpty())
+	s.Zero(sut.Len())
+}
+
+func (s CacheTestSuite) TestCache_Context() {
+	// arrange.
+	cache := header.NewCache(10, nil)
+
+	// action.
+	ctx := header.WithCache(context.Background(), cache)
+
+	// assert.
+	s.Same(cache, header.CacheFrom(ctx))
+	s.Nil(header.CacheFrom(context.Background()))
+	s.Nil(header.CacheFrom(header.WithCache(context.Background(), nil)))
+}
+
+func (s CacheTestSuite) TestCache_Concurrent() {
+	// arrange.
+	sut := header.NewCache(4, nil)
+	const goroutines = 64
+
+	// action.
+	var wg sync.WaitGroup
+	for i := 0; i < goroutines; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, err := sut.Accept([]string{fmt.Sprintf("application/json;q=0.%d", i%8)})
+			s.NoError(err)
+		}(i)
+	}
+	wg.Wait()
+
+	// assert.
+	s.Equal(4, sut.Len())
+}
@@ -0,0 +1,61 @@
+package header_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/freerware/negotiator/header"
+	"github.com/stretchr/testify/suite"
+)
+
+type ConcurrencyTestSuite struct {
+	suite.Suite
+}
+
+func TestConcurrencyTestSuite(t *testing.T) {
+	suite.Run(t, new(ConcurrencyTestSuite))
+}
+
+func (s ConcurrencyTestSuite) TestSharedHeaders() {
+	// arrange.
+	accept, err := header.NewAccept([]string{"text/plain;q=0.1, application/*;q=0.5, application/json, */*;q=0.01"})
+	s.Require().NoError(err)
+	acceptCharset, err := header.NewAcceptCharset([]string{"ascii;q=0.1, iso-8859-1;q=0.5, utf-8"})
+	s.Require().NoError(err)
+	acceptEncoding, err := header.NewAcceptEncoding([]string{"identity;q=0.1, deflate;q=0.5, gzip"})
+	s.Require().NoError(err)
+	acceptLanguage, err := header.NewAcceptLanguage([]string{"fr;q=0.1, en;q=0.5, en-US"})
+	s.Require().NoError(err)
+	negotiate, err := header.NewNegotiate([]string{"vlist, trans, 1.0"})
+	s.Require().NoError(err)
+	const goroutines = 64
+
+	// action.
+	var wg sync.WaitGroup
+	for i := 0; i < goroutines; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ranges := accept.MediaRanges()
+			ranges[0], ranges[len(ranges)-1] = ranges[len(ranges)-1], ranges[0]
+			_, _ = accept.Compatible("application/json")
+			_, _ = acceptCharset.Compatible("utf-8")
+			_, _ = acceptLanguage.Compatible("en-US")
+			_ = acceptEncoding.CodingRanges()
+			_ = acceptLanguage.LanguageRanges()
+			_ = negotiate.Contains(header.NegotiateDirectiveTrans)
+			_ = accept.String()
+		}()
+	}
+	wg.Wait()
+
+	// assert.
+	s.Equal("application/json", accept.MediaRanges()[0].String()[:len("application/json")])
+	s.Equal("utf-8", acceptCharset.CharsetRanges()[0].String()[:len("utf-8")])
+	s.Equal("gzip", acceptEncoding.CodingRanges()[0].CodingRange())
+	s.Equal(header.QualityValueMaximum, acceptLanguage.LanguageRanges()[0].QualityValue())
+	s.Equal(
+		"Accept: text/plain;q=0.100,application/*;q=0.500,application/json,*/*;q=0.010",
+		accept.String(),
+	)
+}
@@ -25,7 +25,7 @@ import (
 	"github.com/stretchr/stew/slice"
 )
 
-var contentCodingRangeRegex = regexp.MustCompile(`^([A-Za-z0-9-]+|\*)(;\s?q=(\d(\.\d{1,3})?))?$`)
+var contentCodingRangeRegex = regexp.MustCompile("^(" + token + ")" + weight + "$")
 
 var (
 	gzip      = "gzip"
@@ -84,13 +84,10 @@ func NewContentCodingRange(contentCoding string) (ContentCodingRange, error) {
 	}
 	groups := contentCodingRangeRegex.FindStringSubmatch(contentCoding)
 
-	if !slice.ContainsString(contentCodings, groups[1]) {
-		return ContentCodingRange{}, ErrInvalidContentCodingRange
-	}
 	cc := ContentCodingRange{coding: groups[1], qValue: QualityValue(1.0)}
 
-	if len(groups[3]) > 0 {
-		q, _ := strconv.ParseFloat(groups[4], 32)
+	if len(groups[2]) > 0 {
+		q, _ := strconv.ParseFloat(groups[3], 32)
 		qv, err := NewQualityValue(float32(q))
 		if err != nil {
 			return ContentCodingRange{}, err
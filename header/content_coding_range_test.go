@@ -18,7 +18,7 @@ package header_test
 import (
 	"testing"
 
-	"github.com/freerware/negotiator/internal/header"
+	"github.com/freerware/negotiator/header"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -48,8 +48,9 @@ func (s *ContentCodingTestSuite) TestContentCoding_NewContentCodingRange() {
 		{"CompressWithQValue", "compress;q=0.9", nil},
 		{"XCompressWithQValue", "x-compress;q=0.9", nil},
 		{"DeflateWithQValue", "deflate;q=0.9", nil},
-		{"Invalid", "zippy", header.ErrInvalidContentCodingRange},
-		{"InvalidWithQValue", "zippy;q=0.5", header.ErrInvalidContentCodingRange},
+		{"Invalid", "zip@py", header.ErrInvalidContentCodingRange},
+		{"Unregistered", "br", nil},
+		{"InvalidWithQValue", "zippy;q=abc", header.ErrInvalidContentCodingRange},
 		{"Empty", "", header.ErrEmptyContentCodingRange},
 	}
 
@@ -15,4 +15,14 @@
 
 // Package header provides implementations that define HTTP header
 // behavior necessary to facilitate content negotiation.
+//
+// Each content negotiation header can be parsed from the lines of the header
+// field, such as with NewAccept, and formatted with ValuesAsString in a form
+// that parses to an equivalent header. Builders, such as AcceptBuilder, can
+// be used to produce well-formed header values:
+//
+//	accept := header.AcceptBuilder().
+//		Add("application/json", 1).
+//		Add("*/*", 0.1).
+//		String() // application/json, */*;q=0.1
 package header
@@ -0,0 +1,184 @@
+/* Copyright 2020 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package header
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// ErrInvalidHeader represents an error encountered when a request header
+// consulted during negotiation is malformed.
+var ErrInvalidHeader = errors.New("invalid header")
+
+// Error represents an error encountered when parsing a request header
+// consulted during negotiation. It describes the malformed element of the
+// header, which is attributable to the user agent.
+type Error struct {
+	// Header is the name of the malformed header.
+	Header string
+
+	// Value is the header field value containing the malformed element.
+	Value string
+
+	// Offset is the byte offset of the malformed element within the header
+	// field value.
+	Offset int
+
+	// Cause is the error encountered when parsing the malformed element.
+	Cause error
+}
+
+// Error provides a textual representation of the header error.
+func (e *Error) Error() string {
+	return fmt.Sprintf(
+		"%s %s: %q at offset %d: %v", ErrInvalidHeader, e.Header, e.Value, e.Offset, e.Cause,
+	)
+}
+
+// Unwrap provides the error encountered when parsing the malformed element.
+func (e *Error) Unwrap() error {
+	return e.Cause
+}
+
+// Is indicates if the header error matches the provided error, which is the
+// case for ErrInvalidHeader.
+func (e *Error) Is(target error) bool {
+	return target == ErrInvalidHeader
+}
+
+// Policy represents how negotiators treat malformed request headers.
+type Policy int
+
+const (
+	// RejectInvalidHeaders fails negotiation with an Error, which handlers
+	// respond to with a 400 HTTP status code.
+	RejectInvalidHeaders Policy = iota
+
+	// IgnoreInvalidHeaders disregards malformed headers entirely, as if
+	// the user agent did not send them.
+	IgnoreInvalidHeaders
+
+	// IgnoreInvalidElements disregards only the malformed elements of
+	// headers, as RFC 9110 recommends for lenient parsing.
+	//
+	// https://www.rfc-editor.org/rfc/rfc9110#section-5.6.1.2
+	IgnoreInvalidElements
+)
+
+// String provides a textual representation of the header policy.
+func (p Policy) String() string {
+	switch p {
+	case RejectInvalidHeaders:
+		return "reject invalid headers"
+	case IgnoreInvalidHeaders:
+		return "ignore invalid headers"
+	case IgnoreInvalidElements:
+		return "ignore invalid elements"
+	default:
+		return "unknown"
+	}
+}
+
+// invalid constructs an error describing the malformed element at the
+// provided offset within the provided value of the header with the provided
+// name.
+func invalid(name, value string, offset int, cause error) error {
+	return &Error{Header: name, Value: value, Offset: offset, Cause: cause}
+}
+
+// parsers parse the values of the request headers consulted during
+// negotiation.
+var parsers = []struct {
+	name  string
+	parse func([]string) error
+}{
+	{headerAccept, func(values []string) error {
+		_, err := NewAccept(values)
+		return err
+	}},
+	{headerAcceptCharset, func(values []string) error {
+		_, err := NewAcceptCharset(values)
+		return err
+	}},
+	{headerAcceptEncoding, func(values []string) error {
+		_, err := NewAcceptEncoding(values)
+		return err
+	}},
+	{headerAcceptFeatures, func(values []string) error {
+		_, err := NewAcceptFeatures(values)
+		return err
+	}},
+	{headerAcceptLanguage, func(values []string) error {
+		_, err := NewAcceptLanguage(values)
+		return err
+	}},
+	{headerNegotiate, func(values []string) error {
+		_, err := NewNegotiate(values)
+		return err
+	}},
+}
+
+// Sanitize applies the provided policy to the malformed request headers
+// consulted during negotiation, providing a copy of the request without them
+// along with the errors describing what was disregarded. The request is
+// provided as is when nothing is disregarded, which is always the case when
+// malformed headers are rejected.
+func Sanitize(r *http.Request, policy Policy) (*http.Request, []error) {
+	if policy == RejectInvalidHeaders {
+		return r, nil
+	}
+
+	var (
+		sanitized = r
+		ignored   []error
+	)
+	for _, p := range parsers {
+		values, ok := r.Header[p.name]
+		if !ok {
+			continue
+		}
+		err := p.parse(values)
+		if err == nil {
+			continue
+		}
+		if sanitized == r {
+			sanitized = r.Clone(r.Context())
+		}
+		if policy == IgnoreInvalidHeaders {
+			ignored = append(ignored, err)
+			sanitized.Header.Del(p.name)
+			continue
+		}
+
+		// keep the well-formed elements.
+		var valid []string
+		for _, e := range elements(values) {
+			if err := p.parse([]string{e.value}); err != nil {
+				ignored = append(ignored, invalid(p.name, e.line, e.offset, errors.Unwrap(err)))
+				continue
+			}
+			valid = append(valid, e.value)
+		}
+		if len(valid) == 0 {
+			sanitized.Header.Del(p.name)
+			continue
+		}
+		sanitized.Header[p.name] = valid
+	}
+	return sanitized, ignored
+}
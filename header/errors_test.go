@@ -0,0 +1,104 @@
+package header_test
+
+import (
+	"errors"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/freerware/negotiator/header"
+	"github.com/stretchr/testify/suite"
+)
+
+type ErrorsTestSuite struct {
+	suite.Suite
+}
+
+func TestErrorsTestSuite(t *testing.T) {
+	suite.Run(t, new(ErrorsTestSuite))
+}
+
+func (s ErrorsTestSuite) TestHeaderError() {
+	tests := []struct {
+		name   string
+		header string
+		value  string
+		offset int
+		parse  func([]string) error
+	}{
+		{"Accept", "Accept", "text/html;q=abc", 0, func(v []string) error {
+			_, err := header.NewAccept(v)
+			return err
+		}},
+		{"AcceptCharset", "Accept-Charset", "utf-8, iso-8859-1;q=2", 7, func(v []string) error {
+			_, err := header.NewAcceptCharset(v)
+			return err
+		}},
+		{"AcceptEncoding", "Accept-Encoding", "gzip, zip@py", 6, func(v []string) error {
+			_, err := header.NewAcceptEncoding(v)
+			return err
+		}},
+		{"AcceptLanguage", "Accept-Language", "en;q=abc", 0, func(v []string) error {
+			_, err := header.NewAcceptLanguage(v)
+			return err
+		}},
+		{"Negotiate", "Negotiate", "trans,  vlist;x", 8, func(v []string) error {
+			_, err := header.NewNegotiate(v)
+			return err
+		}},
+	}
+	for _, test := range tests {
+		s.Run(test.name, func() {
+			// action.
+			err := test.parse([]string{test.value})
+
+			// assert.
+			s.Require().ErrorIs(err, header.ErrInvalidHeader)
+			var headerErr *header.Error
+			s.Require().True(errors.As(err, &headerErr))
+			s.Equal(test.header, headerErr.Header)
+			s.Equal(test.value, headerErr.Value)
+			s.Equal(test.offset, headerErr.Offset)
+			s.NotNil(headerErr.Cause)
+		})
+	}
+}
+
+func (s ErrorsTestSuite) TestSanitize() {
+	tests := []struct {
+		name    string
+		policy  header.Policy
+		accept  []string
+		out     []string
+		ignored int
+	}{
+		{"Reject", header.RejectInvalidHeaders, []string{"text/html;q=abc", "application/json"}, []string{"text/html;q=abc", "application/json"}, 0},
+		{"IgnoreHeader", header.IgnoreInvalidHeaders, []string{"text/html;q=abc", "application/json"}, nil, 1},
+		{"IgnoreElement", header.IgnoreInvalidElements, []string{"text/html;q=abc", "application/json"}, []string{"application/json"}, 1},
+		{"IgnoreAllElements", header.IgnoreInvalidElements, []string{"text/html;q=abc"}, nil, 1},
+		{"IgnoreListElement", header.IgnoreInvalidElements, []string{"text/html;q=abc, application/json"}, []string{"application/json"}, 1},
+		{"WellFormed", header.IgnoreInvalidElements, []string{"application/json"}, []string{"application/json"}, 0},
+	}
+	for _, test := range tests {
+		s.Run(test.name, func() {
+			// arrange.
+			r := httptest.NewRequest("GET", "http://freer.ddns.net/thing", nil)
+			r.Header["Accept"] = test.accept
+			r.Header.Set("Accept-Language", "en-US")
+
+			// action.
+			sanitized, ignored := header.Sanitize(r, test.policy)
+
+			// assert.
+			s.Len(ignored, test.ignored)
+			s.Equal(test.out, sanitized.Header["Accept"])
+			s.Equal("en-US", sanitized.Header.Get("Accept-Language"))
+			s.Equal(test.accept, r.Header["Accept"])
+			if test.ignored == 0 {
+				s.Same(r, sanitized)
+			}
+			for _, err := range ignored {
+				s.ErrorIs(err, header.ErrInvalidHeader)
+			}
+		})
+	}
+}
@@ -56,6 +56,38 @@ func (fl FeatureList) QualityDegradation(supported, unsupported FeatureSet) floa
 	return degradation
 }
 
+// Tags provides the feature tags referenced by the feature predicates within
+// the feature list.
+func (fl FeatureList) Tags() []FeatureTag {
+	var tags []FeatureTag
+	add := func(predicates ...FeaturePredicate) {
+		for _, p := range predicates {
+			tag, ok := predicateTag(p)
+			if !ok {
+				continue
+			}
+			var seen bool
+			for _, t := range tags {
+				if seen = t.Equals(tag); seen {
+					break
+				}
+			}
+			if !seen {
+				tags = append(tags, tag)
+			}
+		}
+	}
+	for _, element := range fl {
+		switch e := element.(type) {
+		case predicateListElement:
+			add(e.predicate)
+		case predicateBagListElement:
+			add(e.predicateBag...)
+		}
+	}
+	return tags
+}
+
 // String provides the textual representation of the feature list.
 func (fl FeatureList) String() string {
 	var s []string
@@ -18,7 +18,7 @@ package header_test
 import (
 	"testing"
 
-	"github.com/freerware/negotiator/internal/header"
+	"github.com/freerware/negotiator/header"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -118,3 +118,32 @@ func (s FeatureListTestSuite) TestFeatureList_String() {
 		})
 	}
 }
+
+func (s FeatureListTestSuite) TestFeatureList_Tags() {
+	tests := []struct {
+		name     string
+		features []string
+		out      []header.FeatureTag
+	}{
+		{"Empty", []string{}, nil},
+		{
+			"WithoutPredicateBag",
+			[]string{"foo", "!bar", "baz=biz", "zip!=zap", "zap=[0-3]"},
+			[]header.FeatureTag{"foo", "bar", "baz", "zip", "zap"},
+		},
+		{
+			"WithPredicateBag",
+			[]string{"foo;+0.5", "[!bar foo=biz]", "zip=[0-3]"},
+			[]header.FeatureTag{"foo", "bar", "zip"},
+		},
+	}
+
+	for _, test := range tests {
+		s.Run(test.name, func() {
+			// action + assert.
+			fl, err := header.NewFeatureList(test.features)
+			s.Require().NoError(err)
+			s.Equal(test.out, fl.Tags())
+		})
+	}
+}
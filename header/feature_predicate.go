@@ -166,6 +166,24 @@ func NewFeaturePredicate(predicate string) (FeaturePredicate, error) {
 	return nil, ErrInvalidPredicate
 }
 
+// predicateTag provides the feature tag the provided feature predicate
+// refers to.
+func predicateTag(fp FeaturePredicate) (FeatureTag, bool) {
+	switch p := fp.(type) {
+	case exists:
+		return p.t, true
+	case absent:
+		return p.t, true
+	case equals:
+		return p.t, true
+	case notEquals:
+		return p.t, true
+	case within:
+		return p.t, true
+	}
+	return "", false
+}
+
 // exists represents a feature predicate that ensures a feature is present.
 type exists struct {
 	t FeatureTag
@@ -51,7 +51,7 @@ func NewLanguageRange(languageRange string) (LanguageRange, error) {
 	}
 
 	parts := strings.Split(languageRange, ";")
-	r := strings.ToLower(parts[0])
+	r := strings.ToLower(strings.TrimSpace(parts[0]))
 	t, err := language.Parse(r)
 	if r != "*" && err != nil {
 		return LanguageRange{}, err
@@ -62,9 +62,8 @@ func NewLanguageRange(languageRange string) (LanguageRange, error) {
 		qValue: QualityValue(1.0),
 	}
 
-	if len(parts) > 1 && strings.HasPrefix(strings.Trim(parts[1], " "), "q=") {
-		w := strings.Trim(parts[1], " ")
-		q := strings.TrimPrefix(w, "q=")
+	if len(parts) > 1 && strings.HasPrefix(strings.ToLower(strings.TrimSpace(parts[1])), "q=") {
+		q := strings.TrimSpace(parts[1])[len("q="):]
 		f, err := strconv.ParseFloat(q, 32)
 		if err != nil {
 			return LanguageRange{}, err
@@ -116,5 +115,9 @@ func (lr LanguageRange) Compatible(tag string) bool {
 
 // String provides a textual representation of the language range.
 func (lr LanguageRange) String() string {
-	return fmt.Sprintf("%s;q=%s", lr.lrange, lr.QualityValue().String())
+	r := lr.lrange
+	if lr.IsTag() {
+		r = lr.Tag()
+	}
+	return fmt.Sprintf("%s;q=%s", r, lr.QualityValue().String())
 }
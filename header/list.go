@@ -0,0 +1,107 @@
+/* Copyright 2020 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package header
+
+import (
+	"regexp"
+	"strings"
+)
+
+// element represents an element of a comma-separated list within a header.
+type element struct {
+	// value is the element without the surrounding whitespace.
+	value string
+
+	// line is the header field value containing the element.
+	line string
+
+	// offset is the byte offset of the element within the header field
+	// value.
+	offset int
+}
+
+// elements splits the provided header field values into the elements of
+// the comma-separated list they form when combined, as defined in RFC 9110.
+// Commas within quoted strings do not delimit elements, while empty elements
+// and the optional whitespace surrounding each element are disregarded.
+//
+// https://www.rfc-editor.org/rfc/rfc9110#section-5.6.1
+func elements(values []string) []element {
+	var elems []element
+	for _, line := range values {
+		var (
+			start    int
+			quoted   bool
+			escaped  bool
+			appendTo = func(end int) {
+				value := strings.TrimLeft(line[start:end], " \t")
+				offset := start + (end - start - len(value))
+				value = strings.TrimRight(value, " \t")
+				if len(value) > 0 {
+					elems = append(elems, element{value: value, line: line, offset: offset})
+				}
+			}
+		)
+		for idx := 0; idx < len(line); idx++ {
+			switch c := line[idx]; {
+			case escaped:
+				escaped = false
+			case quoted && c == '\\':
+				escaped = true
+			case c == '"':
+				quoted = !quoted
+			case !quoted && c == ',':
+				appendTo(idx)
+				start = idx + 1
+			}
+		}
+		appendTo(len(line))
+	}
+	return elems
+}
+
+const (
+	// token is the pattern matching a token, as defined in RFC 9110.
+	//
+	// https://www.rfc-editor.org/rfc/rfc9110#section-5.6.2
+	token = "[!#$%&'*+.^_`|~0-9A-Za-z-]+"
+
+	// weight is the pattern matching the optional weight of an element,
+	// capturing the quality value, as defined in RFC 9110.
+	//
+	// https://www.rfc-editor.org/rfc/rfc9110#section-12.4.2
+	weight = `(\s*;\s*[qQ]=(\d(?:\.\d{0,3})?))?`
+)
+
+// tokenRegex matches values consisting of a single token.
+var tokenRegex = regexp.MustCompile("^" + token + "$")
+
+// quote provides the provided value as a quoted string, as defined in
+// RFC 9110.
+//
+// https://www.rfc-editor.org/rfc/rfc9110#section-5.6.4
+func quote(value string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for _, c := range []byte(value) {
+		if c == '"' || c == '\\' {
+			b.WriteByte('\\')
+		}
+		b.WriteByte(c)
+	}
+	b.WriteByte('"')
+	return b.String()
+}
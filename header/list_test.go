@@ -0,0 +1,127 @@
+package header
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type ListTestSuite struct {
+	suite.Suite
+}
+
+func TestListTestSuite(t *testing.T) {
+	suite.Run(t, new(ListTestSuite))
+}
+
+func (s ListTestSuite) TestElements() {
+	tests := []struct {
+		name string
+		in   []string
+		out  []element
+	}{
+		{"None", nil, nil},
+		{"Empty", []string{""}, nil},
+		{"Single", []string{"gzip"}, []element{{"gzip", "gzip", 0}}},
+		{
+			"CommaSeparated",
+			[]string{"gzip,deflate"},
+			[]element{{"gzip", "gzip,deflate", 0}, {"deflate", "gzip,deflate", 5}},
+		},
+		{
+			"OptionalWhitespace",
+			[]string{" gzip ,\tdeflate\t"},
+			[]element{{"gzip", " gzip ,\tdeflate\t", 1}, {"deflate", " gzip ,\tdeflate\t", 8}},
+		},
+		{
+			"EmptyElements",
+			[]string{",gzip, ,,deflate,"},
+			[]element{{"gzip", ",gzip, ,,deflate,", 1}, {"deflate", ",gzip, ,,deflate,", 9}},
+		},
+		{
+			"MultipleLines",
+			[]string{"gzip", "deflate, br"},
+			[]element{{"gzip", "gzip", 0}, {"deflate", "deflate, br", 0}, {"br", "deflate, br", 9}},
+		},
+		{
+			"QuotedString",
+			[]string{`text/plain;format="a,b", text/html`},
+			[]element{
+				{`text/plain;format="a,b"`, `text/plain;format="a,b", text/html`, 0},
+				{"text/html", `text/plain;format="a,b", text/html`, 25},
+			},
+		},
+		{
+			"QuotedPair",
+			[]string{`text/plain;format="a\",b", text/html`},
+			[]element{
+				{`text/plain;format="a\",b"`, `text/plain;format="a\",b", text/html`, 0},
+				{"text/html", `text/plain;format="a\",b", text/html`, 27},
+			},
+		},
+	}
+	for _, test := range tests {
+		s.Run(test.name, func() {
+			// action.
+			elems := elements(test.in)
+
+			// assert.
+			s.Equal(test.out, elems)
+		})
+	}
+}
+
+func (s ListTestSuite) TestConformance() {
+	tests := []struct {
+		name   string
+		header string
+		in     []string
+		count  int
+	}{
+		{"ChromeAccept", headerAccept, []string{"text/html,application/xhtml+xml,application/xml;q=0.9,image/avif,image/webp,image/apng,*/*;q=0.8,application/signed-exchange;v=b3;q=0.7"}, 8},
+		{"ChromeAcceptEncoding", headerAcceptEncoding, []string{"gzip, deflate, br, zstd"}, 4},
+		{"ChromeAcceptLanguage", headerAcceptLanguage, []string{"en-US,en;q=0.9"}, 2},
+		{"FirefoxAccept", headerAccept, []string{"text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8"}, 4},
+		{"FirefoxImageAccept", headerAccept, []string{"image/avif,image/webp,image/png,image/svg+xml,image/*;q=0.8,*/*;q=0.5"}, 6},
+		{"FirefoxAcceptEncoding", headerAcceptEncoding, []string{"gzip, deflate, br, zstd"}, 4},
+		{"FirefoxAcceptLanguage", headerAcceptLanguage, []string{"en-US,en;q=0.5"}, 2},
+		{"SafariAccept", headerAccept, []string{"text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8"}, 4},
+		{"SafariAcceptEncoding", headerAcceptEncoding, []string{"gzip, deflate, br"}, 3},
+		{"SafariAcceptLanguage", headerAcceptLanguage, []string{"en-GB,en;q=0.9"}, 2},
+		{"CurlAccept", headerAccept, []string{"*/*"}, 1},
+		{"CurlAcceptEncoding", headerAcceptEncoding, []string{"deflate, gzip, br, zstd"}, 4},
+		{"AcceptCharset", headerAcceptCharset, []string{"ISO-8859-1,utf-8;q=0.7,*;q=0.7"}, 3},
+		{"SplitAcrossLines", headerAccept, []string{"text/html", "", "application/json;q=0.5, */*;q=0.1"}, 3},
+	}
+	for _, test := range tests {
+		s.Run(test.name, func() {
+			// action.
+			var (
+				count int
+				err   error
+			)
+			switch test.header {
+			case headerAccept:
+				var a Accept
+				a, err = NewAccept(test.in)
+				count = len(a.MediaRanges())
+			case headerAcceptCharset:
+				var ac AcceptCharset
+				ac, err = NewAcceptCharset(test.in)
+				count = len(ac.CharsetRanges())
+			case headerAcceptEncoding:
+				var ae AcceptEncoding
+				ae, err = NewAcceptEncoding(test.in)
+				count = len(ae.CodingRanges())
+			case headerAcceptLanguage:
+				var al AcceptLanguage
+				al, err = NewAcceptLanguage(test.in)
+				count = len(al.LanguageRanges())
+			}
+
+			// assert.
+			s.Require().NoError(err)
+			s.Equal(test.count, count)
+		})
+	}
+}
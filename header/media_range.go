@@ -19,6 +19,7 @@ import (
 	"errors"
 	"fmt"
 	"mime"
+	"sort"
 	"strconv"
 	"strings"
 )
@@ -43,6 +44,7 @@ type MediaRange struct {
 	subT   string
 	params map[string]string
 	qValue QualityValue
+	qSet   bool
 }
 
 func NewMediaRange(mediaRange string) (MediaRange, error) {
@@ -75,6 +77,8 @@ func parseMediaRange(m string) (MediaRange, error) {
 			return MediaRange{}, err
 		}
 		mr.qValue = qv
+		mr.qSet = true
+		delete(params, "q")
 	}
 	return mr, nil
 }
@@ -136,6 +140,12 @@ func (mr MediaRange) QualityValue() QualityValue {
 	return mr.qValue
 }
 
+// HasQualityValue indicates if the quality value of the media range was
+// provided explicitly with the 'q' parameter, regardless of its value.
+func (mr MediaRange) HasQualityValue() bool {
+	return mr.qSet
+}
+
 // Compatible determines if the provided media type is compatible with
 // the media range.
 func (mr MediaRange) Compatible(mediaType string) (bool, error) {
@@ -172,19 +182,26 @@ func (mr MediaRange) Precedence() int {
 	return 2 + len(mr.params)
 }
 
-// String provides the textual representation of the media range.
+// String provides the textual representation of the media range, with the
+// parameters in lexical order followed by the quality value when it was
+// provided explicitly.
 func (mr MediaRange) String() string {
-	var params []string
-	params = append(params, fmt.Sprintf("q=%s", mr.QualityValue().String()))
-	for p, v := range mr.params {
-		if p == "q" {
-			continue
-		}
-		params = append(params, fmt.Sprintf("%s=%s", p, v))
+	var names []string
+	for p := range mr.params {
+		names = append(names, p)
 	}
+	sort.Strings(names)
+
 	t := fmt.Sprintf("%s/%s", mr.Type(), mr.SubType())
-	if len(params) > 0 {
-		t = fmt.Sprintf("%s;%s", t, strings.Join(params, ";"))
+	for _, p := range names {
+		v := mr.params[p]
+		if !tokenRegex.MatchString(v) {
+			v = quote(v)
+		}
+		t = fmt.Sprintf("%s;%s=%s", t, p, v)
+	}
+	if !mr.HasQualityValue() {
+		return t
 	}
-	return t
+	return fmt.Sprintf("%s;q=%s", t, mr.QualityValue().String())
 }
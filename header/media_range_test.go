@@ -19,7 +19,7 @@ import (
 	"strings"
 	"testing"
 
-	"github.com/freerware/negotiator/internal/header"
+	"github.com/freerware/negotiator/header"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -192,7 +192,7 @@ func (s *MediaRangeTestSuite) TestMediaRange_Precedence() {
 		mediaRange string
 		out        int
 	}{
-		{"WithQValue", "application/json;q=0.5", 3},
+		{"WithQValue", "application/json;q=0.5", 2},
 		{"WithoutQValue", "application/json", 2},
 		{"Uppercase", "APPLICATION/JSON", 2},
 		{"Wildcard", "*/*", 0},
@@ -255,15 +255,38 @@ func (s *MediaRangeTestSuite) TestMediaRange_QualityValue() {
 	}
 }
 
+func (s *MediaRangeTestSuite) TestMediaRange_HasQualityValue() {
+	tests := []struct {
+		name       string
+		mediaRange string
+		out        bool
+	}{
+		{"WithoutQValue", "application/json", false},
+		{"WithMaximumQValue", "application/json;q=1", true},
+		{"WithQValue", "application/json;q=0.5", true},
+		{"WithQuotedQ", `application/json;format="q=0.5"`, false},
+	}
+
+	for _, test := range tests {
+		s.Run(test.name, func() {
+			// action + assert.
+			c, err := header.NewMediaRange(test.mediaRange)
+			s.Require().NoError(err)
+			s.Equal(test.out, c.HasQualityValue())
+		})
+	}
+}
+
 func (s *MediaRangeTestSuite) TestMediaRange_String() {
 	tests := []struct {
 		name       string
 		mediaRange string
 		out        string
 	}{
-		{"WithoutQValueNoParams", "application/json", "application/json;q=1.000"},
-		{"WithoutQValueWithParams", "application/json;foo=bar", "application/json;q=1.000;foo=bar"},
+		{"WithoutQValueNoParams", "application/json", "application/json"},
+		{"WithoutQValueWithParams", "application/json;foo=bar", "application/json;foo=bar"},
 		{"WithQValue", "application/json;q=0.5", "application/json;q=0.500"},
+		{"WithMaximumQValue", "application/json;q=1", "application/json;q=1.000"},
 	}
 
 	for _, test := range tests {
@@ -25,35 +25,40 @@ var (
 	headerNegotiate = "Negotiate"
 
 	// EmptyNegotiateHeader is an empty Negotiate header.
-	EmptyNegotiateHeader = Negotiate([]NegotiateDirective{})
+	EmptyNegotiateHeader = Negotiate{directives: []NegotiateDirective{}}
 )
 
 // Negotiate represents the Negotiate header.
-type Negotiate []NegotiateDirective
+//
+// A Negotiate header is immutable once constructed, and therefore safe for
+// concurrent use.
+type Negotiate struct {
+	directives []NegotiateDirective
+}
 
 // NewNegotiate constructs a Negotiate header with the provided directives.
 func NewNegotiate(directives []string) (Negotiate, error) {
 	var dirs []NegotiateDirective
-	for _, d := range directives {
-		directive, err := NewNegotiateDirective(d)
+	for _, e := range elements(directives) {
+		directive, err := NewNegotiateDirective(e.value)
 		if err != nil {
-			return EmptyNegotiateHeader, err
+			return EmptyNegotiateHeader, invalid(headerNegotiate, e.line, e.offset, err)
 		}
 		dirs = append(dirs, directive)
 	}
-	return Negotiate(dirs), nil
+	return Negotiate{directives: dirs}, nil
 }
 
 // Directives provides the negotiation directives.
 func (n Negotiate) Directives() []NegotiateDirective {
-	return n
+	return append([]NegotiateDirective(nil), n.directives...)
 }
 
 // Contains determines if the Negotiate header contains at least one of the
 // provided directives.
 func (n Negotiate) Contains(directives ...NegotiateDirective) (matches bool) {
 	for _, dir := range directives {
-		for _, d := range n.Directives() {
+		for _, d := range n.directives {
 			if matches = strings.EqualFold(d.String(), dir.String()); matches {
 				return
 			}
@@ -65,7 +70,7 @@ func (n Negotiate) Contains(directives ...NegotiateDirective) (matches bool) {
 // ContainsRVSA determines if the Negotiate header contains an RVSA algorithm
 // that matches the version provided.
 func (n Negotiate) ContainsRVSA(version string) (matches bool) {
-	for _, d := range n.Directives() {
+	for _, d := range n.directives {
 		rvsaDir := NegotiateDirective(version)
 		if matches = d.IsRVSAVersion() &&
 			strings.EqualFold(rvsaDir.String(), d.String()); matches {
@@ -75,11 +80,32 @@ func (n Negotiate) ContainsRVSA(version string) (matches bool) {
 	return
 }
 
-// String provides the textual representation of the Negotiate header.
-func (n Negotiate) String() string {
+// SupportsTCN determines if the Negotiate header indicates that the user agent
+// supports transparent content negotiation for the current request, which is
+// the case when it contains the 'trans' directive or any of the directives
+// that imply it.
+//
+// https://tools.ietf.org/html/rfc2295#section-8.4
+func (n Negotiate) SupportsTCN() bool {
+	for _, d := range n.directives {
+		if !d.IsExtension() {
+			return true
+		}
+	}
+	return false
+}
+
+// ValuesAsString provides the value of the Negotiate header, which can be
+// parsed to construct an equivalent Negotiate header.
+func (n Negotiate) ValuesAsString() string {
 	var s []string
-	for _, d := range n.Directives() {
+	for _, d := range n.directives {
 		s = append(s, d.String())
 	}
-	return fmt.Sprintf("%s: %s", headerNegotiate, strings.Join(s, ","))
+	return strings.Join(s, ",")
+}
+
+// String provides the textual representation of the Negotiate header.
+func (n Negotiate) String() string {
+	return fmt.Sprintf("%s: %s", headerNegotiate, n.ValuesAsString())
 }
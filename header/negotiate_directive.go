@@ -20,9 +20,15 @@ import (
 	"strconv"
 )
 
-// ErrEmptyNegotiateDirective is an error that indicates that the
-// negotiate directive cannot be empty.
-var ErrEmptyNegotiateDirective = errors.New("negotiate directive cannot be empty")
+var (
+	// ErrEmptyNegotiateDirective is an error that indicates that the
+	// negotiate directive cannot be empty.
+	ErrEmptyNegotiateDirective = errors.New("negotiate directive cannot be empty")
+
+	// ErrInvalidNegotiateDirective is an error that indicates that the
+	// negotiate directive is invalid.
+	ErrInvalidNegotiateDirective = errors.New("negotiate directive is invalid")
+)
 
 // NegotiateDirective represents a directive specified within the Negotiate
 // header.
@@ -51,6 +57,9 @@ func NewNegotiateDirective(directive string) (NegotiateDirective, error) {
 	if len(directive) == 0 {
 		return NegotiateDirective(""), ErrEmptyNegotiateDirective
 	}
+	if !tokenRegex.MatchString(directive) {
+		return NegotiateDirective(""), ErrInvalidNegotiateDirective
+	}
 	return NegotiateDirective(directive), nil
 }
 
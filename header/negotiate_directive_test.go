@@ -18,7 +18,7 @@ package header_test
 import (
 	"testing"
 
-	"github.com/freerware/negotiator/internal/header"
+	"github.com/freerware/negotiator/header"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -37,7 +37,10 @@ func (s NegotiateDirectiveTestSuite) TestNegotiateDirective_NewNegotiateDirectiv
 		err  error
 	}{
 		{"ValidDirective", header.NegotiateDirectiveGuessSmall.String(), nil},
-		{"InvalidDirective", "", header.ErrEmptyNegotiateDirective},
+		{"Extension", "x-directive", nil},
+		{"RVSAVersion", "1.0", nil},
+		{"EmptyDirective", "", header.ErrEmptyNegotiateDirective},
+		{"InvalidDirective", "vlist;x", header.ErrInvalidNegotiateDirective},
 	}
 
 	for _, test := range tests {
@@ -3,7 +3,7 @@ package header_test
 import (
 	"testing"
 
-	"github.com/freerware/negotiator/internal/header"
+	"github.com/freerware/negotiator/header"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -24,7 +24,9 @@ func (s NegotiateTestSuite) TestNegotiate_NewNegotiate() {
 		{"SingleDirective", []string{header.NegotiateDirectiveVList.String()}, nil},
 		{"MultipleDirectives", []string{header.NegotiateDirectiveTrans.String(), header.NegotiateDirectiveVList.String()}, nil},
 		{"Empty", []string{}, nil},
-		{"InvalidDirective", []string{""}, header.ErrEmptyNegotiateDirective},
+		{"CommaSeparated", []string{"trans, vlist"}, nil},
+		{"EmptyElements", []string{"", " , trans"}, nil},
+		{"InvalidDirective", []string{"trans, vlist;x"}, header.ErrInvalidNegotiateDirective},
 	}
 
 	for _, test := range tests {
@@ -34,7 +36,7 @@ func (s NegotiateTestSuite) TestNegotiate_NewNegotiate() {
 
 			// assert.
 			if test.err != nil {
-				s.Require().EqualError(err, test.err.Error())
+				s.Require().ErrorIs(err, test.err)
 			} else {
 				s.Require().NoError(err)
 			}
@@ -150,6 +152,31 @@ func (s NegotiateTestSuite) TestNegotiate_ContainsRVSA() {
 	}
 }
 
+func (s NegotiateTestSuite) TestNegotiate_SupportsTCN() {
+	tests := []struct {
+		name       string
+		directives []string
+		out        bool
+	}{
+		{"Trans", []string{"trans"}, true},
+		{"VList", []string{"vlist"}, true},
+		{"GuessSmall", []string{"guess-small"}, true},
+		{"RVSA", []string{"1.0"}, true},
+		{"Wildcard", []string{"*"}, true},
+		{"Extension", []string{"x-thing"}, false},
+		{"Empty", []string{}, false},
+	}
+
+	for _, test := range tests {
+		s.Run(test.name, func() {
+			// action + assert.
+			n, err := header.NewNegotiate(test.directives)
+			s.Require().NoError(err)
+			s.Equal(test.out, n.SupportsTCN())
+		})
+	}
+}
+
 func (s NegotiateTestSuite) TestNegotiate_String() {
 	tests := []struct {
 		name       string
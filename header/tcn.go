@@ -18,12 +18,24 @@ package header
 import (
 	"errors"
 	"fmt"
+	"regexp"
 	"strings"
 )
 
-// ErrEmptyTCNValue is an error that indicates that the TCN value cannot
-// be empty.
-var ErrEmptyTCNValue = errors.New("TCN value cannot be empty")
+var (
+	// ErrEmptyTCNValue is an error that indicates that the TCN value cannot
+	// be empty.
+	ErrEmptyTCNValue = errors.New("TCN value cannot be empty")
+
+	// ErrInvalidTCNValue is an error that indicates that the TCN value is
+	// invalid.
+	ErrInvalidTCNValue = errors.New("TCN value is invalid")
+
+	// tcnValueRegex matches a TCN value, as defined in RFC 2295.
+	//
+	// https://tools.ietf.org/html/rfc2295#section-8.5
+	tcnValueRegex = regexp.MustCompile(`^` + token + `(\s*=\s*(` + token + `|"([^"\\]|\\.)*"))?$`)
+)
 
 // ResponseType represents the type of transparent negotiation response type.
 type ResponseType string
@@ -78,21 +90,33 @@ func NewTCNValue(value string) (TCNValue, error) {
 	if len(value) == 0 {
 		return TCNValue(""), ErrEmptyTCNValue
 	}
+	if !tcnValueRegex.MatchString(value) {
+		return TCNValue(""), fmt.Errorf("%w: %q", ErrInvalidTCNValue, value)
+	}
 	return TCNValue(value), nil
 }
 
-// IsExtension indicates if the TCN value is an extension.
-func (v TCNValue) IsExtension() bool {
-	override := map[OverrideDirective]bool{
-		OverrideDirectiveReChoose: true,
-		OverrideDirectiveKeep:     true,
+// IsResponseType indicates if the TCN value is a response type.
+func (v TCNValue) IsResponseType() bool {
+	switch ResponseType(v) {
+	case ResponseTypeList, ResponseTypeChoice, ResponseTypeAdhoc:
+		return true
 	}
-	responseType := map[ResponseType]bool{
-		ResponseTypeList:   true,
-		ResponseTypeChoice: true,
-		ResponseTypeAdhoc:  true,
+	return false
+}
+
+// IsOverride indicates if the TCN value is an override directive.
+func (v TCNValue) IsOverride() bool {
+	switch OverrideDirective(v) {
+	case OverrideDirectiveReChoose, OverrideDirectiveKeep:
+		return true
 	}
-	return !override[OverrideDirective(v)] && !responseType[ResponseType(v)]
+	return false
+}
+
+// IsExtension indicates if the TCN value is an extension.
+func (v TCNValue) IsExtension() bool {
+	return !v.IsOverride() && !v.IsResponseType()
 }
 
 // String provides the textual representation of the TCN value.
@@ -118,8 +142,8 @@ func NewTCN(values []string) (TCN, error) {
 		return EmptyTCN, nil
 	}
 	var vals []TCNValue
-	for _, value := range values {
-		val, err := NewTCNValue(value)
+	for _, e := range elements(values) {
+		val, err := NewTCNValue(e.value)
 		if err != nil {
 			return EmptyTCN, err
 		}
@@ -128,17 +152,62 @@ func NewTCN(values []string) (TCN, error) {
 	return TCN(vals), nil
 }
 
+// ResponseType retrieves the response type, which is empty when absent.
+func (t TCN) ResponseType() ResponseType {
+	for _, v := range t {
+		if v.IsResponseType() {
+			return ResponseType(v)
+		}
+	}
+	return ResponseType("")
+}
+
+// Overrides retrieves the override directives.
+func (t TCN) Overrides() []OverrideDirective {
+	var overrides []OverrideDirective
+	for _, v := range t {
+		if v.IsOverride() {
+			overrides = append(overrides, OverrideDirective(v))
+		}
+	}
+	return overrides
+}
+
+// Extensions retrieves the extension values.
+func (t TCN) Extensions() []TCNValue {
+	var extensions []TCNValue
+	for _, v := range t {
+		if v.IsExtension() {
+			extensions = append(extensions, v)
+		}
+	}
+	return extensions
+}
+
+// Add constructs a TCN header with the provided values added, such as override
+// directives and extensions, leaving this header unmodified.
+func (t TCN) Add(values ...TCNValue) TCN {
+	return append(t[:len(t):len(t)], values...)
+}
+
 // String provides the textual representation of the TCN header value.
 func (t TCN) String() string {
 	return fmt.Sprintf("%s: %s", headerTCN, t.ValuesAsString())
 }
 
 // ValuesAsStrings provides the string representation for each value of
-// for the TCN header.
+// for the TCN header, with the response type first, followed by the override
+// directives and then the extensions.
 func (t TCN) ValuesAsStrings() []string {
 	var s []string
-	for _, v := range t {
-		s = append(s, v.String())
+	if rt := t.ResponseType(); len(rt) > 0 {
+		s = append(s, rt.String())
+	}
+	for _, o := range t.Overrides() {
+		s = append(s, o.String())
+	}
+	for _, e := range t.Extensions() {
+		s = append(s, e.String())
 	}
 	return s
 }
@@ -0,0 +1,63 @@
+package header_test
+
+import (
+	"testing"
+
+	"github.com/freerware/negotiator/header"
+	"github.com/stretchr/testify/suite"
+)
+
+type TCNTestSuite struct {
+	suite.Suite
+}
+
+func TestTCNTestSuite(t *testing.T) {
+	suite.Run(t, new(TCNTestSuite))
+}
+
+func (s TCNTestSuite) TestNewTCN() {
+	tests := []struct {
+		name       string
+		values     []string
+		rt         header.ResponseType
+		overrides  []header.OverrideDirective
+		extensions []header.TCNValue
+		err        error
+	}{
+		{"ResponseType", []string{"list"}, header.ResponseTypeList, nil, nil, nil},
+		{"Override", []string{"choice, keep"}, header.ResponseTypeChoice, []header.OverrideDirective{header.OverrideDirectiveKeep}, nil, nil},
+		{"Extensions", []string{`adhoc, re-choose, x-a, x-b=1, x-c="d, e"`}, header.ResponseTypeAdhoc, []header.OverrideDirective{header.OverrideDirectiveReChoose}, []header.TCNValue{"x-a", "x-b=1", `x-c="d, e"`}, nil},
+		{"NoResponseType", []string{"keep"}, "", []header.OverrideDirective{header.OverrideDirectiveKeep}, nil, nil},
+		{"Invalid", []string{"list, x=(a)"}, "", nil, nil, header.ErrInvalidTCNValue},
+	}
+	for _, test := range tests {
+		s.Run(test.name, func() {
+			// action.
+			tcn, err := header.NewTCN(test.values)
+
+			// assert.
+			if test.err != nil {
+				s.ErrorIs(err, test.err)
+				return
+			}
+			s.Require().NoError(err)
+			s.Equal(test.rt, tcn.ResponseType())
+			s.Equal(test.overrides, tcn.Overrides())
+			s.Equal(test.extensions, tcn.Extensions())
+		})
+	}
+}
+
+func (s TCNTestSuite) TestTCN_Add() {
+	// arrange.
+	base := header.EmptyTCN.Add("x-thing")
+
+	// action.
+	list := base.Add(header.TCNValue(header.OverrideDirectiveReChoose), header.TCNValue(header.ResponseTypeList))
+	choice := base.Add(header.TCNValue(header.ResponseTypeChoice))
+
+	// assert.
+	s.Equal("x-thing", base.ValuesAsString())
+	s.Equal("list,re-choose,x-thing", list.ValuesAsString())
+	s.Equal("TCN: choice,x-thing", choice.String())
+}
@@ -0,0 +1,344 @@
+/* Copyright 2020 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package header
+
+import (
+	"errors"
+	"fmt"
+	"mime"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/freerware/negotiator/representation"
+)
+
+// ErrMetadataOnly is an error that indicates that the representation only
+// consists of metadata, such as the representations described within the
+// Alternates header.
+var ErrMetadataOnly = errors.New("representation only consists of metadata")
+
+// variantAttributes represents the various dimensions of a variant described
+// within a variant description.
+type variantAttributes map[string]interface{}
+
+// String provides the textual representation of the variant attributes,
+// ordered as they are defined in RFC 2295 followed by the extension
+// attributes, omitting those that are empty.
+func (a variantAttributes) String() string {
+	var s []string
+	for _, name := range []string{
+		variantAttributeType,
+		variantAttributeCharset,
+		variantAttributeLanguage,
+		variantAttributeLength,
+		variantAttributeFeatures,
+		variantAttributeDescription,
+	} {
+		if value := a.format(name); len(value) > 0 {
+			s = append(s, fmt.Sprintf("{%s %s}", name, value))
+		}
+	}
+
+	// sort the extensions for deterministic output.
+	var extensions []string
+	for name := range a {
+		if !isVariantAttribute(name) {
+			extensions = append(extensions, name)
+		}
+	}
+	sort.Strings(extensions)
+	for _, name := range extensions {
+		value, _ := a[name].(string)
+		if value = extensionValue(strings.TrimSpace(value)); len(value) == 0 {
+			s = append(s, fmt.Sprintf("{%s}", name))
+			continue
+		}
+		s = append(s, fmt.Sprintf("{%s %s}", name, value))
+	}
+	return strings.Join(s, " ")
+}
+
+// format provides the textual representation of the value for the attribute
+// with the provided name, which is empty when the attribute is absent.
+func (a variantAttributes) format(name string) string {
+	switch value := a[name].(type) {
+	case int:
+		return strconv.Itoa(value)
+	case variantText:
+		if len(value.text) == 0 {
+			return ""
+		}
+		return value.String()
+	case string:
+		value = strings.TrimSpace(value)
+		switch name {
+		case variantAttributeType:
+			return formatMediaType(value)
+		case variantAttributeCharset:
+			if len(value) == 0 || tokenRegex.MatchString(value) {
+				return value
+			}
+			return quote(value)
+		case variantAttributeLanguage:
+			var tags []string
+			for _, tag := range strings.Split(value, ",") {
+				if tag = strings.TrimSpace(tag); len(tag) > 0 {
+					tags = append(tags, tag)
+				}
+			}
+			return strings.Join(tags, ",")
+		case variantAttributeFeatures:
+			return strings.Join(strings.Fields(value), " ")
+		}
+		return value
+	}
+	return ""
+}
+
+// formatMediaType provides the textual representation of the provided media
+// type, quoting the parameter values that are not tokens.
+func formatMediaType(value string) string {
+	mediaType, params, err := mime.ParseMediaType(value)
+	if err != nil {
+		return value
+	}
+	var names []string
+	for name := range params {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		v := params[name]
+		if !tokenRegex.MatchString(v) {
+			v = quote(v)
+		}
+		mediaType = mediaType + ";" + name + "=" + v
+	}
+	return mediaType
+}
+
+// extensionValue provides the provided extension attribute value as is when
+// it is well-formed, and as a quoted string otherwise.
+func extensionValue(value string) string {
+	p := alternatesParser{line: value}
+	for !p.done() {
+		switch c := p.peek(); {
+		case c == '"':
+			if _, err := p.quotedString(); err != nil {
+				return quote(value)
+			}
+		case c == '}' || c < ' ' || c == 0x7f:
+			return quote(value)
+		default:
+			p.pos++
+		}
+	}
+	return value
+}
+
+// variantText represents the textual description of a variant, along with
+// the language the description is written in.
+type variantText struct {
+	text     string
+	language string
+}
+
+// String provides the textual representation of the variant description
+// text.
+func (t variantText) String() string {
+	if len(t.language) == 0 {
+		return quote(t.text)
+	}
+	return fmt.Sprintf("%s %s", quote(t.text), t.language)
+}
+
+// VariantDescription represents the complete description of a variant,
+// including it's URL, source quality, and attributes.
+//
+// https://tools.ietf.org/html/rfc2295#section-5
+type VariantDescription struct {
+	uri           url.URL
+	sourceQuality float32
+	attributes    variantAttributes
+}
+
+// URI retrieves the URI of the variant.
+func (a VariantDescription) URI() url.URL {
+	return a.uri
+}
+
+// SourceQuality retrieves the source quality of the variant.
+func (a VariantDescription) SourceQuality() float32 {
+	return a.sourceQuality
+}
+
+// Type retrieves the media type of the variant.
+func (a VariantDescription) Type() string {
+	t, _ := a.attributes[variantAttributeType].(string)
+	return t
+}
+
+// Charset retrieves the charset of the variant.
+func (a VariantDescription) Charset() string {
+	c, _ := a.attributes[variantAttributeCharset].(string)
+	return c
+}
+
+// Languages retrieves the language tags of the variant.
+func (a VariantDescription) Languages() []string {
+	l, _ := a.attributes[variantAttributeLanguage].(string)
+	var languages []string
+	for _, tag := range strings.Split(l, ",") {
+		if tag = strings.TrimSpace(tag); len(tag) > 0 {
+			languages = append(languages, tag)
+		}
+	}
+	return languages
+}
+
+// Length retrieves the size of the variant in bytes, along with an indication
+// of whether the size is known.
+func (a VariantDescription) Length() (int, bool) {
+	l, ok := a.attributes[variantAttributeLength].(int)
+	return l, ok
+}
+
+// Features retrieves the feature list of the variant.
+func (a VariantDescription) Features() []string {
+	f, _ := a.attributes[variantAttributeFeatures].(string)
+	return strings.Fields(f)
+}
+
+// Description retrieves the textual description of the variant, along with
+// the language tag of the language it is written in, if any.
+func (a VariantDescription) Description() (string, string) {
+	d, _ := a.attributes[variantAttributeDescription].(variantText)
+	return d.text, d.language
+}
+
+// Extensions retrieves the extension attributes of the variant, keyed on
+// their names.
+func (a VariantDescription) Extensions() map[string]string {
+	extensions := make(map[string]string)
+	for name, value := range a.attributes {
+		if v, ok := value.(string); ok && !isVariantAttribute(name) {
+			extensions[name] = v
+		}
+	}
+	return extensions
+}
+
+// Representation provides a representation consisting solely of the metadata
+// within the variant description.
+func (a VariantDescription) Representation() representation.Representation {
+	return describedRepresentation{description: a}
+}
+
+// String provides the textual representation of the variant description.
+func (a VariantDescription) String() string {
+	attributes := a.attributes.String()
+	if len(attributes) > 0 {
+		attributes = " " + attributes
+	}
+	return fmt.Sprintf("{%s %.3f%s}", quote(a.uri.String()), a.sourceQuality, attributes)
+}
+
+// isVariantAttribute determines if the provided attribute name is one of the
+// variant attributes defined in RFC 2295, as opposed to an extension.
+func isVariantAttribute(name string) bool {
+	switch name {
+	case variantAttributeType,
+		variantAttributeCharset,
+		variantAttributeLanguage,
+		variantAttributeLength,
+		variantAttributeFeatures,
+		variantAttributeDescription:
+		return true
+	}
+	return false
+}
+
+// describedRepresentation represents a representation known only through
+// its variant description.
+type describedRepresentation struct {
+	description VariantDescription
+}
+
+// ContentLocation retrieves the location of the representation.
+func (r describedRepresentation) ContentLocation() url.URL {
+	return r.description.URI()
+}
+
+// ContentType retrieves the media type of the representation.
+func (r describedRepresentation) ContentType() string {
+	return r.description.Type()
+}
+
+// ContentEncoding retrieves the content codings of the representation, which
+// variant descriptions do not convey.
+func (r describedRepresentation) ContentEncoding() []string {
+	return nil
+}
+
+// ContentCharset retrieves the charset of the representation.
+func (r describedRepresentation) ContentCharset() string {
+	return r.description.Charset()
+}
+
+// ContentLanguage retrieves the language of the representation.
+func (r describedRepresentation) ContentLanguage() string {
+	return strings.Join(r.description.Languages(), ", ")
+}
+
+// ContentFeatures retrieves the feature list of the representation.
+func (r describedRepresentation) ContentFeatures() []string {
+	return r.description.Features()
+}
+
+// SourceQuality retrieves the source quality of the representation.
+func (r describedRepresentation) SourceQuality() float32 {
+	return r.description.SourceQuality()
+}
+
+// ContentLengthHint provides the content length of the representation when
+// conveyed by the variant description.
+func (r describedRepresentation) ContentLengthHint() (int, bool) {
+	return r.description.Length()
+}
+
+// Bytes fails, as the representation only consists of metadata.
+func (r describedRepresentation) Bytes() ([]byte, error) {
+	return nil, ErrMetadataOnly
+}
+
+// FromBytes fails, as the representation only consists of metadata.
+func (r describedRepresentation) FromBytes([]byte) error {
+	return ErrMetadataOnly
+}
+
+// ContentDescription provides the textual description of the representation
+// when conveyed by the variant description.
+func (r describedRepresentation) ContentDescription() (string, string) {
+	return r.description.Description()
+}
+
+// ExtensionAttributes provides the extension attributes of the representation
+// conveyed by the variant description.
+func (r describedRepresentation) ExtensionAttributes() map[string]string {
+	return r.description.Extensions()
+}
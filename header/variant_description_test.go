@@ -0,0 +1,67 @@
+package header_test
+
+import (
+	"testing"
+
+	"github.com/freerware/negotiator/header"
+	"github.com/freerware/negotiator/representation"
+	"github.com/stretchr/testify/suite"
+)
+
+type VariantDescriptionTestSuite struct {
+	suite.Suite
+}
+
+func TestVariantDescriptionTestSuite(t *testing.T) {
+	suite.Run(t, new(VariantDescriptionTestSuite))
+}
+
+func (s VariantDescriptionTestSuite) TestVariantDescription_Representation() {
+	// arrange.
+	a, err := header.ParseAlternates([]string{
+		`{"paper.1" 0.9 {type text/html} {charset utf-8} {language en, fr} {length 120} {features tables}}`,
+	})
+	s.Require().NoError(err)
+
+	// action.
+	rep := a.Variants()[0].Representation()
+
+	// assert.
+	location := rep.ContentLocation()
+	s.Equal("paper.1", location.String())
+	s.Equal("text/html", rep.ContentType())
+	s.Equal("utf-8", rep.ContentCharset())
+	s.Equal("en, fr", rep.ContentLanguage())
+	s.Empty(rep.ContentEncoding())
+	s.Equal([]string{"tables"}, rep.ContentFeatures())
+	s.Equal(float32(0.9), rep.SourceQuality())
+	length, err := representation.ContentLength(rep)
+	s.Require().NoError(err)
+	s.Equal(120, length)
+	_, err = rep.Bytes()
+	s.ErrorIs(err, header.ErrMetadataOnly)
+	s.ErrorIs(rep.FromBytes([]byte("thing")), header.ErrMetadataOnly)
+}
+
+func (s VariantDescriptionTestSuite) TestVariantDescription_Defaults() {
+	// arrange.
+	a, err := header.ParseAlternates([]string{`{"paper.1" 1}`})
+	s.Require().NoError(err)
+
+	// action.
+	v := a.Variants()[0]
+
+	// assert.
+	s.Empty(v.Type())
+	s.Empty(v.Charset())
+	s.Empty(v.Languages())
+	s.Empty(v.Features())
+	s.Empty(v.Extensions())
+	_, ok := v.Length()
+	s.False(ok)
+	text, tag := v.Description()
+	s.Empty(text)
+	s.Empty(tag)
+	_, err = representation.ContentLength(v.Representation())
+	s.ErrorIs(err, header.ErrMetadataOnly)
+}
@@ -0,0 +1,104 @@
+/* Copyright 2020 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package header
+
+import (
+	"fmt"
+	"net/textproto"
+	"strings"
+)
+
+var (
+	// headerVary is the header key for the Vary header.
+	headerVary = "Vary"
+
+	// EmptyVary is an empty Vary header.
+	EmptyVary = Vary([]string{})
+)
+
+// Vary represents the Vary header.
+//
+// The "Vary" header field in a response describes what parts of a request
+// message, aside from the method, Host header field, and request target,
+// might influence the origin server's process for selecting and
+// representing this response.
+type Vary []string
+
+// NewVary constructs a Vary header with the provided values. Each value can
+// contain a comma separated list of field names.
+func NewVary(values []string) Vary {
+	if len(values) == 0 {
+		return EmptyVary
+	}
+	var v Vary
+	for _, value := range values {
+		v = v.Add(strings.Split(value, ",")...)
+	}
+	return v
+}
+
+// Add introduces the provided field names into the Vary header, ignoring
+// those already present. Field names are compared case-insensitively.
+func (v Vary) Add(fields ...string) Vary {
+	vv := append(Vary{}, v...)
+	for _, f := range fields {
+		f = strings.TrimSpace(f)
+		if len(f) == 0 || vv.Contains(f) {
+			continue
+		}
+		if f != "*" {
+			f = textproto.CanonicalMIMEHeaderKey(f)
+		}
+		vv = append(vv, f)
+	}
+	return vv
+}
+
+// Contains determines if the Vary header contains the provided field name.
+func (v Vary) Contains(field string) bool {
+	for _, f := range v {
+		if strings.EqualFold(f, field) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsWildcard indicates if the Vary header contains the '*' member, which
+// signals that other aspects of the request might play a role in selecting
+// the response.
+func (v Vary) IsWildcard() bool {
+	return v.Contains("*")
+}
+
+// IsEmpty indicates if the Vary header is empty.
+func (v Vary) IsEmpty() bool {
+	return len(v) == len(EmptyVary)
+}
+
+// ValuesAsString provides a single string containing all of the values for
+// the Vary header.
+func (v Vary) ValuesAsString() string {
+	if v.IsWildcard() {
+		return "*"
+	}
+	return strings.Join(v, ", ")
+}
+
+// String provides the textual representation of the Vary header.
+func (v Vary) String() string {
+	return fmt.Sprintf("%s: %s", headerVary, v.ValuesAsString())
+}
@@ -0,0 +1,103 @@
+/* Copyright 2020 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package header_test
+
+import (
+	"testing"
+
+	"github.com/freerware/negotiator/header"
+	"github.com/stretchr/testify/suite"
+)
+
+type VaryTestSuite struct {
+	suite.Suite
+}
+
+func TestVaryTestSuite(t *testing.T) {
+	suite.Run(t, new(VaryTestSuite))
+}
+
+func (s VaryTestSuite) TestVary_NewVary() {
+	tests := []struct {
+		name string
+		in   []string
+		out  header.Vary
+	}{
+		{"SingleField", []string{"Accept"}, header.Vary{"Accept"}},
+		{"MultipleFields", []string{"accept, accept-language"}, header.Vary{"Accept", "Accept-Language"}},
+		{"MultipleValues", []string{"Accept", "Negotiate"}, header.Vary{"Accept", "Negotiate"}},
+		{"Duplicates", []string{"Accept", "accept"}, header.Vary{"Accept"}},
+		{"EmptyElements", []string{", Accept ,,"}, header.Vary{"Accept"}},
+		{"Empty", []string{}, header.EmptyVary},
+	}
+
+	for _, test := range tests {
+		s.Run(test.name, func() {
+			// action + assert.
+			s.Equal(test.out, header.NewVary(test.in))
+		})
+	}
+}
+
+func (s VaryTestSuite) TestVary_Add() {
+	// arrange.
+	v := header.NewVary([]string{"Cookie"})
+
+	// action.
+	vv := v.Add("accept", "Cookie", "Accept-Language")
+
+	// assert.
+	s.Equal(header.Vary{"Cookie"}, v)
+	s.Equal(header.Vary{"Cookie", "Accept", "Accept-Language"}, vv)
+}
+
+func (s VaryTestSuite) TestVary_IsWildcard() {
+	tests := []struct {
+		name string
+		in   []string
+		out  bool
+	}{
+		{"Wildcard", []string{"Accept, *"}, true},
+		{"NotWildcard", []string{"Accept"}, false},
+		{"Empty", []string{}, false},
+	}
+
+	for _, test := range tests {
+		s.Run(test.name, func() {
+			// action + assert.
+			s.Equal(test.out, header.NewVary(test.in).IsWildcard())
+		})
+	}
+}
+
+func (s VaryTestSuite) TestVary_String() {
+	tests := []struct {
+		name string
+		in   []string
+		out  string
+	}{
+		{"Empty", []string{}, "Vary: "},
+		{"MultipleFields", []string{"Accept", "Accept-Language"}, "Vary: Accept, Accept-Language"},
+		{"Wildcard", []string{"Accept", "*"}, "Vary: *"},
+	}
+
+	for _, test := range tests {
+		s.Run(test.name, func() {
+			// action + assert.
+			s.Equal(test.out, header.NewVary(test.in).String())
+		})
+	}
+}
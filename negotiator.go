@@ -33,3 +33,9 @@ type NegotiationContext struct {
 type Negotiator interface {
 	Negotiate(NegotiationContext, ...representation.Representation) error
 }
+
+// Decider represents a content negotiator capable of deciding the outcome
+// of negotiation without responding to the user agent.
+type Decider interface {
+	Decide(*http.Request, ...representation.Representation) (Decision, error)
+}
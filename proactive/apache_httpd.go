@@ -22,7 +22,7 @@ import (
 	"strconv"
 	"strings"
 
-	"github.com/freerware/negotiator/internal/header"
+	"github.com/freerware/negotiator/header"
 	"github.com/freerware/negotiator/representation"
 )
 
@@ -31,18 +31,30 @@ import (
 // https://httpd.apache.org/docs/2.4/content-negotiation.html
 type httpd struct {
 	filters []filter
+	options HTTPDOptions
 }
 
 // ApacheHTTPD provides the Apache HTTP server proactive content
-// negotiation algorithm.
-func ApacheHTTPD() representation.Chooser {
+// negotiation algorithm with the options provided.
+func ApacheHTTPD(options ...HTTPDOption) representation.Chooser {
+	// set defaults.
+	o := HTTPDOptions{
+		WildcardFiddling:      true,
+		ForceLanguagePriority: ForceLanguagePriorityPrefer,
+	}
+	// apply options.
+	for _, opt := range options {
+		opt(&o)
+	}
 	filters := []filter{
 		// step 2.1
 		bestSourceAndType,
+		// feature negotiation, as described in RFC 2295.
+		bestFeatures,
 		// step 2.2
 		bestLanguage,
 		// step 2.3
-		bestLanguageOrder,
+		bestLanguageOrder(o),
 		// step 2.4
 		bestLevel,
 		// step 2.5
@@ -54,7 +66,18 @@ func ApacheHTTPD() representation.Chooser {
 		// step 2.8
 		smallestContentLength,
 	}
-	return httpd{filters: filters}
+	return httpd{filters: filters, options: o}
+}
+
+// Dimensions provides the dimensions consulted by the algorithm.
+func (c httpd) Dimensions() []representation.Dimension {
+	return []representation.Dimension{
+		representation.DimensionMediaType,
+		representation.DimensionLanguage,
+		representation.DimensionCharset,
+		representation.DimensionEncoding,
+		representation.DimensionFeatures,
+	}
 }
 
 // Chooser determines the 'best' representation from the provided set.
@@ -66,51 +89,79 @@ func (c httpd) Choose(
 		ae  header.AcceptEncoding
 		al  header.AcceptLanguage
 		ac  header.AcceptCharset
+		af  header.AcceptFeatures
 		err error
 	)
 
+	cache := header.CacheFrom(r.Context())
 	accept := r.Header["Accept"]
-	if a, err = header.NewAccept(accept); err != nil {
+	if a, err = cache.Accept(accept); err != nil {
 		return nil, err
 	}
 
 	acceptEncoding := r.Header["Accept-Encoding"]
-	if ae, err = header.NewAcceptEncoding(acceptEncoding); err != nil {
+	if ae, err = cache.AcceptEncoding(acceptEncoding); err != nil {
 		return nil, err
 	}
 
 	acceptLanguage := r.Header["Accept-Language"]
-	if al, err = header.NewAcceptLanguage(acceptLanguage); err != nil {
+	if al, err = cache.AcceptLanguage(acceptLanguage); err != nil {
 		return nil, err
 	}
 
 	acceptCharset := r.Header["Accept-Charset"]
-	if ac, err = header.NewAcceptCharset(acceptCharset); err != nil {
+	if ac, err = cache.AcceptCharset(acceptCharset); err != nil {
+		return nil, err
+	}
+
+	acceptFeatures := r.Header["Accept-Features"]
+	if af, err = cache.AcceptFeatures(acceptFeatures); err != nil {
 		return nil, err
 	}
 
-	var variants representation.Set
+	// https://httpd.apache.org/docs/2.4/content-negotiation.html#better
+	fiddle := c.options.WildcardFiddling && !explicitQualityValue(a)
+
+	var variants, unacceptableLanguage representation.Set
 	for _, rp := range reps {
-		qt := c.acceptQuality(rp, a)
+		qt := c.acceptQuality(rp, a, fiddle)
 		qc := c.acceptCharsetQuality(rp, ac)
 		ql, los := c.acceptLanguageQuality(rp, al)
 		qe := c.acceptEncodingQuality(rp, ae)
+		// malformed feature lists are treated as empty rather than failing
+		// negotiation or eliminating the variant.
+		qf, err := acceptFeaturesQuality(rp, af)
+		if err != nil {
+			qf = header.QualityValueMaximum.Float()
+		}
 
 		shouldEliminate := qt == header.QualityValueMinimum || qc == header.QualityValueMinimum ||
-			qe == header.QualityValueMinimum || ql == header.QualityValueMinimum
+			qe == header.QualityValueMinimum || qf == 0
 		if shouldEliminate {
 			continue
 		}
 
-		variants = append(variants, representation.RankedRepresentation{
+		ranked := representation.RankedRepresentation{
 			Representation:        rp,
 			SourceQualityValue:    rp.SourceQuality(),
 			MediaTypeQualityValue: qt.Float(),
 			CharsetQualityValue:   qc.Float(),
 			EncodingQualityValue:  qe.Float(),
 			LanguageQualityValue:  ql.Float(),
+			FeatureQualityValue:   qf,
 			LanguageOrderScore:    los,
-		})
+		}
+		if ql == header.QualityValueMinimum {
+			unacceptableLanguage = append(unacceptableLanguage, ranked)
+			continue
+		}
+		variants = append(variants, ranked)
+	}
+
+	// https://httpd.apache.org/docs/2.4/mod/mod_negotiation.html#forcelanguagepriority
+	fallback := c.options.ForceLanguagePriority&ForceLanguagePriorityFallback != 0
+	if variants.Empty() && fallback && len(c.options.LanguagePriority) > 0 {
+		variants = unacceptableLanguage
 	}
 
 	for _, f := range c.filters {
@@ -131,32 +182,33 @@ func (c httpd) Choose(
 }
 
 var (
-	// smallestContentLength selects the variants with the smallest content length.
+	// smallestContentLength selects the variants with the smallest content
+	// length. The content length of each variant is determined once, using
+	// the content length hint when one is available. Variants are not
+	// comparable when the content length of any of them is unknown, in which
+	// case none are eliminated rather than serializing them.
 	smallestContentLength filter = func(variants representation.Set) (representation.Set, error) {
-		variants.Sort(func(i, j int) bool {
-			// sort smallest to largest.
-			var f, s int
-			if bytes, err := variants[i].Bytes(); err == nil {
-				f = len(bytes)
+		lowest, lengths := -1, make([]int, variants.Size())
+		for i, v := range variants {
+			length, known, err := representation.KnownContentLength(v.Representation)
+			if err != nil {
+				return representation.EmptySet, err
 			}
-			if bytes, err := variants[j].Bytes(); err == nil {
-				s = len(bytes)
+			if !known {
+				return variants, nil
 			}
-			return f < s
-		})
-		lowest := variants.First()
-		lowestBytes, err := lowest.Bytes()
-		if err != nil {
-			return representation.EmptySet, err
+			if lowest == -1 || length < lowest {
+				lowest = length
+			}
+			lengths[i] = length
 		}
-		lowestLength := len(lowestBytes)
-		return variants.Where(func(v representation.RankedRepresentation) bool {
-			var length int
-			if bytes, err := v.Bytes(); err == nil {
-				length = len(bytes)
+		var smallest representation.Set
+		for i, v := range variants {
+			if lengths[i] == lowest {
+				smallest = append(smallest, v)
 			}
-			return length == lowestLength
-		}), nil
+		}
+		return smallest, nil
 	}
 
 	// bestEncoding selects the variants with the best encoding.
@@ -227,6 +279,18 @@ var (
 		}), nil
 	}
 
+	// bestFeatures selects the variants with the best feature quality
+	// degradation factor.
+	bestFeatures filter = func(variants representation.Set) (representation.Set, error) {
+		variants.Sort(func(i, j int) bool {
+			return variants[i].FeatureQualityValue > variants[j].FeatureQualityValue
+		})
+		highest := variants.First()
+		return variants.Where(func(v representation.RankedRepresentation) bool {
+			return v.FeatureQualityValue == highest.FeatureQualityValue
+		}), nil
+	}
+
 	// bestLanguage selects the variants with the best language.
 	bestLanguage filter = func(variants representation.Set) (representation.Set, error) {
 		variants.Sort(func(i, j int) bool {
@@ -242,15 +306,35 @@ var (
 		}), nil
 	}
 
-	// bestLanguageOrder selects the variants with best language order score.
-	bestLanguageOrder filter = func(variants representation.Set) (representation.Set, error) {
-		variants.Sort(func(i, j int) bool {
-			return variants[i].LanguageOrderScore < variants[j].LanguageOrderScore
-		})
-		highest := variants.First()
-		return variants.Where(func(v representation.RankedRepresentation) bool {
-			return v.LanguageOrderScore == highest.LanguageOrderScore
-		}), nil
+	// bestLanguageOrder selects the variants with best language order score,
+	// which is determined by the order of the languages in the
+	// Accept-Language header. The language priority is leveraged when the
+	// Accept-Language header doesn't express an order, or to choose amongst
+	// the remaining variants when preferred.
+	bestLanguageOrder = func(o HTTPDOptions) filter {
+		prefer := o.ForceLanguagePriority&ForceLanguagePriorityPrefer != 0
+		return func(variants representation.Set) (representation.Set, error) {
+			variants.Sort(func(i, j int) bool {
+				return variants[i].LanguageOrderScore > variants[j].LanguageOrderScore
+			})
+			highest := variants.First()
+			variants = variants.Where(func(v representation.RankedRepresentation) bool {
+				return v.LanguageOrderScore == highest.LanguageOrderScore
+			})
+			if len(o.LanguagePriority) == 0 ||
+				(highest.LanguageOrderScore > 0 && !prefer) {
+				return variants, nil
+			}
+			best := languagePriorityIndex(o.LanguagePriority, highest.ContentLanguage())
+			for _, v := range variants {
+				if idx := languagePriorityIndex(o.LanguagePriority, v.ContentLanguage()); idx < best {
+					best = idx
+				}
+			}
+			return variants.Where(func(v representation.RankedRepresentation) bool {
+				return languagePriorityIndex(o.LanguagePriority, v.ContentLanguage()) == best
+			}), nil
+		}
 	}
 
 	// bestLevel selects the variants with the highest 'level' media parameter.
@@ -289,6 +373,7 @@ var (
 func (c httpd) acceptQuality(
 	rep representation.Representation,
 	accept header.Accept,
+	fiddle bool,
 ) header.QualityValue {
 	qt := header.QualityValueMinimum
 	if rep.ContentType() == "" || accept.IsEmpty() {
@@ -298,6 +383,12 @@ func (c httpd) acceptQuality(
 			compatible, err := mr.Compatible(rep.ContentType())
 			if compatible && err == nil {
 				qt = mr.QualityValue()
+				switch {
+				case fiddle && mr.IsTypeWildcard():
+					qt = header.QualityValue(0.01)
+				case fiddle && mr.IsSubTypeWildcard():
+					qt = header.QualityValue(0.02)
+				}
 				break
 			}
 		}
@@ -336,10 +427,11 @@ func (c httpd) acceptLanguageQuality(
 	if rep.ContentLanguage() == "" || acceptLanguage.IsEmpty() {
 		ql = header.QualityValueMaximum
 	} else {
-		for idx, lr := range acceptLanguage {
+		ranges := acceptLanguage.Specified()
+		for idx, lr := range ranges {
 			if lr.Compatible(rep.ContentLanguage()) {
 				ql = lr.QualityValue()
-				los = len(acceptLanguage) - idx
+				los = len(ranges) - idx
 				break
 			}
 		}
@@ -366,6 +458,52 @@ func (c httpd) acceptEncodingQuality(
 	return header.QualityValueMinimum
 }
 
+// acceptFeaturesQuality determines the quality degradation factor for a
+// representations feature list based on the Accept-Features header. Feature
+// lists are disregarded when the header is absent.
+func acceptFeaturesQuality(
+	rep representation.Representation,
+	acceptFeatures header.AcceptFeatures,
+) (float32, error) {
+	if len(rep.ContentFeatures()) == 0 || acceptFeatures.IsEmpty() {
+		return header.QualityValueMaximum.Float(), nil
+	}
+	featureList, err := header.NewFeatureList(rep.ContentFeatures())
+	if err != nil {
+		return 0, err
+	}
+	degradation, _ := acceptFeatures.QualityDegradation(featureList)
+	return degradation, nil
+}
+
+// explicitQualityValue determines if any of the media ranges within the
+// Accept header provide a 'q' parameter, regardless of its value.
+func explicitQualityValue(a header.Accept) bool {
+	for _, mr := range a.MediaRanges() {
+		if mr.HasQualityValue() {
+			return true
+		}
+	}
+	return false
+}
+
+// languagePriorityIndex determines the position of the provided language
+// within the language priority, where languages that are absent are
+// positioned last. Language tags within the priority match languages they
+// are a prefix of, such that 'en' matches 'en-GB'.
+func languagePriorityIndex(priority []string, language string) int {
+	for idx, p := range priority {
+		for _, l := range strings.Split(language, ",") {
+			l = strings.TrimSpace(l)
+			if strings.EqualFold(l, p) ||
+				(len(l) > len(p) && strings.EqualFold(l[:len(p)], p) && l[len(p)] == '-') {
+				return idx
+			}
+		}
+	}
+	return len(priority)
+}
+
 type hwl struct {
 	v representation.RankedRepresentation
 	l int
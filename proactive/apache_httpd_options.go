@@ -0,0 +1,87 @@
+/* Copyright 2020 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package proactive
+
+// ForceLanguagePriorityMode indicates when the language priority is leveraged
+// beyond choosing amongst representations for user agents that don't express
+// a language preference.
+type ForceLanguagePriorityMode int
+
+const (
+	// ForceLanguagePriorityPrefer indicates that the language priority is
+	// leveraged to choose one representation when several are equally
+	// acceptable.
+	ForceLanguagePriorityPrefer ForceLanguagePriorityMode = 1 << iota
+
+	// ForceLanguagePriorityFallback indicates that the language priority is
+	// leveraged to choose a representation when none of the representations
+	// have a language that is acceptable.
+	ForceLanguagePriorityFallback
+)
+
+// HTTPDOptions represents the configuration options for the Apache HTTP
+// server proactive content negotiation algorithm.
+type HTTPDOptions struct {
+	WildcardFiddling      bool
+	LanguagePriority      []string
+	ForceLanguagePriority ForceLanguagePriorityMode
+}
+
+// HTTPDOption represents a configurable option for the Apache HTTP server
+// proactive content negotiation algorithm.
+type HTTPDOption func(*HTTPDOptions)
+
+// Options that can be used to configure the Apache HTTP server proactive
+// content negotiation algorithm.
+var (
+	// DisableWildcardFiddling deactivates the assignment of a quality value
+	// of 0.01 to the '*/*' media range and 0.02 to 'type/*' media ranges
+	// when none of the media ranges in the Accept header have an explicit
+	// quality value, even one of 1.
+	//
+	// https://httpd.apache.org/docs/2.4/content-negotiation.html#better
+	DisableWildcardFiddling = func() HTTPDOption {
+		return func(o *HTTPDOptions) {
+			o.WildcardFiddling = false
+		}
+	}
+
+	// LanguagePriority defines the precedence of languages, from highest
+	// to lowest, which is leveraged to choose amongst representations when
+	// the user agent doesn't express a language preference.
+	//
+	// https://httpd.apache.org/docs/2.4/mod/mod_negotiation.html#languagepriority
+	LanguagePriority = func(languages ...string) HTTPDOption {
+		return func(o *HTTPDOptions) {
+			o.LanguagePriority = languages
+		}
+	}
+
+	// ForceLanguagePriority defines when the language priority is leveraged
+	// beyond choosing amongst representations for user agents that don't
+	// express a language preference. As with httpd, the default is
+	// ForceLanguagePriorityPrefer; providing no modes disables both.
+	//
+	// https://httpd.apache.org/docs/2.4/mod/mod_negotiation.html#forcelanguagepriority
+	ForceLanguagePriority = func(modes ...ForceLanguagePriorityMode) HTTPDOption {
+		return func(o *HTTPDOptions) {
+			o.ForceLanguagePriority = 0
+			for _, m := range modes {
+				o.ForceLanguagePriority |= m
+			}
+		}
+	}
+)
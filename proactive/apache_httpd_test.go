@@ -42,7 +42,7 @@ func (s *ApacheHTTPDTestSuite) TestApacheHTTPD_Choose_NewAcceptEncodingErr() {
 	// arrange.
 	request := httptest.NewRequest("GET", "http://freer.ddns.net/thing", nil)
 	request.Header.Add("Accept", "application/json")
-	request.Header.Add("Accept-Encoding", "invalid")
+	request.Header.Add("Accept-Encoding", "gzip;q=abc")
 	variants := []representation.Representation{}
 
 	// action.
@@ -73,7 +73,7 @@ func (s *ApacheHTTPDTestSuite) TestApacheHTTPD_Choose_NewAcceptCharsetErr() {
 	request.Header.Add("Accept", "application/json")
 	request.Header.Add("Accept-Encoding", "gzip")
 	request.Header.Add("Accept-Language", "en-US")
-	request.Header.Add("Accept-Charset", "")
+	request.Header.Add("Accept-Charset", "ascii;q=abc")
 	variants := []representation.Representation{}
 
 	// action.
@@ -359,6 +359,231 @@ func (s *ApacheHTTPDTestSuite) TestApacheHTTPD_Choose_SmallestContentLength() {
 	s.Equal(v1, chosen)
 }
 
+func (s *ApacheHTTPDTestSuite) TestApacheHTTPD_Choose_BestFeatures() {
+	// arrange.
+	variant := func(qs float32, features ...string) representation.Representation {
+		b := _representation.NewBuilder().
+			WithType("text/html").
+			WithSourceQuality(qs)
+		for _, f := range features {
+			b = b.WithFeature(f)
+		}
+		return b.Build(test.RepresentationBuilderFunc)
+	}
+	tables, plain := variant(1.0, "tables;-0.5"), variant(1.0)
+	frames := variant(1.0, "frames")
+	tests := []struct {
+		name           string
+		acceptFeatures []string
+		variants       []representation.Representation
+		chosen         representation.Representation
+	}{
+		{"Supported", []string{"tables"}, []representation.Representation{tables, plain}, tables},
+		{"Degraded", []string{"!tables"}, []representation.Representation{tables, plain}, plain},
+		{"Unsupported", []string{"tables"}, []representation.Representation{frames}, nil},
+		{"UnmentionedWithWildcard", []string{"tables, *"}, []representation.Representation{frames}, nil},
+		{"MissingAcceptFeatures", nil, []representation.Representation{frames}, frames},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			// arrange.
+			request := httptest.NewRequest("GET", "http://freer.ddns.net/thing", nil)
+			request.Header.Add("Accept", "text/html")
+			request.Header["Accept-Features"] = tt.acceptFeatures
+
+			// action.
+			chosen, err := s.sut.Choose(request, tt.variants...)
+
+			// assert.
+			s.Require().NoError(err)
+			s.Equal(tt.chosen, chosen)
+		})
+	}
+}
+
+func (s *ApacheHTTPDTestSuite) TestApacheHTTPD_Choose_InvalidFeatures() {
+	// arrange.
+	request := httptest.NewRequest("GET", "http://freer.ddns.net/thing", nil)
+	request.Header.Add("Accept-Features", "tables")
+	invalid := _representation.NewBuilder().
+		WithType("text/html").
+		WithSourceQuality(1.0).
+		WithFeature("tables;+abc").
+		Build(test.RepresentationBuilderFunc)
+	valid := _representation.NewBuilder().
+		WithType("text/html").
+		WithSourceQuality(0.5).
+		Build(test.RepresentationBuilderFunc)
+
+	// action.
+	chosen, err := s.sut.Choose(request, invalid, valid)
+	only, onlyErr := s.sut.Choose(request, invalid)
+
+	// assert.
+	s.Require().NoError(err)
+	s.Equal(invalid, chosen)
+	s.Require().NoError(onlyErr)
+	s.Equal(invalid, only)
+}
+
+func (s *ApacheHTTPDTestSuite) TestApacheHTTPD_Choose_WildcardFiddling() {
+	// arrange.
+	json := _representation.NewBuilder().
+		WithType("application/json").
+		WithSourceQuality(1.0).
+		Build(test.RepresentationBuilderFunc)
+	html := _representation.NewBuilder().
+		WithType("text/html").
+		WithSourceQuality(0.9).
+		Build(test.RepresentationBuilderFunc)
+	tests := []struct {
+		name    string
+		options []HTTPDOption
+		accept  string
+		chosen  representation.Representation
+	}{
+		{"Fiddled", nil, "text/*, */*", html},
+		{"ExplicitMaximum", nil, "text/*, */*;q=1", json},
+		{"ExplicitQualityValue", nil, "text/*, */*;q=0.95", json},
+		{"QuotedComma", nil, `text/*, */*, image/png;format="a, b"`, html},
+		{"QuotedQualityValue", nil, `text/*, */*, image/png;format="a,x/y;q=1,b"`, html},
+		{"QuotedCommaExplicit", nil, `text/*, */*;q=1, image/png;format="a, b"`, json},
+		{"Disabled", []HTTPDOption{DisableWildcardFiddling()}, "text/*, */*", json},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			// arrange.
+			sut := ApacheHTTPD(tt.options...)
+			request := httptest.NewRequest("GET", "http://freer.ddns.net/thing", nil)
+			request.Header.Add("Accept", tt.accept)
+
+			// action.
+			chosen, err := sut.Choose(request, json, html)
+
+			// assert.
+			s.Require().NoError(err)
+			s.Equal(tt.chosen, chosen)
+		})
+	}
+}
+
+func (s *ApacheHTTPDTestSuite) TestApacheHTTPD_Choose_LanguagePriority() {
+	// arrange.
+	variant := func(language string) representation.Representation {
+		return _representation.NewBuilder().
+			WithType("text/html").
+			WithLanguage(language).
+			WithSourceQuality(1.0).
+			Build(test.RepresentationBuilderFunc)
+	}
+	english, french, german := variant("en-GB"), variant("fr"), variant("de")
+	tests := []struct {
+		name           string
+		options        []HTTPDOption
+		acceptLanguage string
+		chosen         representation.Representation
+	}{
+		{"AcceptLanguageOrder", nil, "fr, en", french},
+		{"Priority", []HTTPDOption{LanguagePriority("de", "en")}, "", german},
+		{"PriorityPrefix", []HTTPDOption{LanguagePriority("en", "de")}, "", english},
+		{"PriorityIgnored", []HTTPDOption{LanguagePriority("de")}, "fr, en", french},
+		{
+			"Prefer",
+			[]HTTPDOption{LanguagePriority("fr"), ForceLanguagePriority(ForceLanguagePriorityPrefer)},
+			"*",
+			french,
+		},
+		{"PreferByDefault", []HTTPDOption{LanguagePriority("fr")}, "*", french},
+		{"PreferDisabled", []HTTPDOption{LanguagePriority("fr"), ForceLanguagePriority()}, "*", english},
+		{
+			"Fallback",
+			[]HTTPDOption{
+				LanguagePriority("de", "fr"),
+				ForceLanguagePriority(ForceLanguagePriorityPrefer, ForceLanguagePriorityFallback),
+			},
+			"ja",
+			german,
+		},
+		{"WithoutFallback", []HTTPDOption{LanguagePriority("de", "fr")}, "ja", nil},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			// arrange.
+			sut := ApacheHTTPD(tt.options...)
+			request := httptest.NewRequest("GET", "http://freer.ddns.net/thing", nil)
+			if len(tt.acceptLanguage) > 0 {
+				request.Header.Add("Accept-Language", tt.acceptLanguage)
+			}
+
+			// action.
+			chosen, err := sut.Choose(request, english, french, german)
+
+			// assert.
+			s.Require().NoError(err)
+			s.Equal(tt.chosen, chosen)
+		})
+	}
+}
+
 func (s *ApacheHTTPDTestSuite) TearDownTest() {
 	s.sut = nil
 }
+
+func (s *ApacheHTTPDTestSuite) TestApacheHTTPD_Choose_SmallestContentLengthHint() {
+	// arrange.
+	_json, english, ascii := "application/json", "en-US", "ascii"
+	request := httptest.NewRequest("GET", "http://freer.ddns.net/thing", nil)
+	request.Header.Add("Accept", _json)
+	invocations := 0
+	lazy := func(length int) *representation.Lazy[string] {
+		l := representation.NewLazy(func() (string, error) {
+			invocations++
+			return "thing", nil
+		})
+		l.SetContentType(_json)
+		l.SetContentLanguage(english)
+		l.SetContentCharset(ascii)
+		l.SetSourceQuality(1.0)
+		l.SetContentLength(length)
+		return l
+	}
+	v1, v2 := lazy(100), lazy(10)
+	variants := []representation.Representation{v1, v2}
+
+	// action.
+	chosen, err := s.sut.Choose(request, variants...)
+
+	// assert.
+	s.Require().NoError(err)
+	s.Equal(v2, chosen)
+	s.Zero(invocations)
+}
+
+func (s *ApacheHTTPDTestSuite) TestApacheHTTPD_Choose_UnknownContentLength() {
+	// arrange.
+	_json, english, ascii := "application/json", "en-US", "ascii"
+	request := httptest.NewRequest("GET", "http://freer.ddns.net/thing", nil)
+	request.Header.Add("Accept", _json)
+	invocations := 0
+	lazy := func() *representation.Lazy[string] {
+		l := representation.NewLazy(func() (string, error) {
+			invocations++
+			return "thing", nil
+		})
+		l.SetContentType(_json)
+		l.SetContentLanguage(english)
+		l.SetContentCharset(ascii)
+		l.SetSourceQuality(1.0)
+		return l
+	}
+	v1, v2 := lazy(), lazy()
+	v2.SetContentLength(10)
+
+	// action.
+	chosen, err := s.sut.Choose(request, v1, v2)
+
+	// assert.
+	s.Require().NoError(err)
+	s.NotNil(chosen)
+	s.Zero(invocations)
+}
@@ -0,0 +1,232 @@
+/* Copyright 2020 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package proactive
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/freerware/negotiator/header"
+	"github.com/freerware/negotiator/representation"
+)
+
+var (
+	// defaultCompressionThreshold is the minimum content length, in bytes, of
+	// representations to compress by default.
+	defaultCompressionThreshold = 1024
+
+	// defaultCompressibleTypes are the media types of representations to
+	// compress by default.
+	defaultCompressibleTypes = []string{
+		"text/*",
+		"application/json",
+		"application/xml",
+		"application/yaml",
+		"application/javascript",
+		"application/problem+json",
+		"application/problem+xml",
+		"image/svg+xml",
+	}
+
+	// contentCodingPreferences ranks the content codings that are equally
+	// acceptable to the user agent, from most preferred to least.
+	contentCodingPreferences = []string{
+		"gzip",
+		"deflate",
+		"compress",
+		"x-gzip",
+		"x-compress",
+	}
+)
+
+// compress applies the content coding most acceptable to the user agent to
+// the provided representation when dynamic compression is enabled.
+func (n Negotiator) compress(
+	r *http.Request, rep representation.Representation,
+) (representation.Representation, error) {
+	if !n.compression || !n.compressible(rep) {
+		return rep, nil
+	}
+
+	// only compress when the user agent indicates the content codings it
+	// supports.
+	values, ok := r.Header["Accept-Encoding"]
+	if !ok {
+		return rep, nil
+	}
+	acceptEncoding, err := n.headerCache.AcceptEncoding(values)
+	if err != nil {
+		return nil, err
+	}
+	coding, identity := bestContentCoding(acceptEncoding)
+	if len(coding) == 0 {
+		if !identity {
+			return nil, errNoAcceptableContentCoding
+		}
+		return rep, nil
+	}
+
+	// the threshold is disregarded when the identity coding is refused, as
+	// the representation cannot be responded with as is.
+	if identity {
+		var reached bool
+		if reached, err = n.reachesThreshold(rep); err != nil || !reached {
+			return rep, err
+		}
+	}
+	return representation.NewEncoded(rep, coding)
+}
+
+// errNoAcceptableContentCoding indicates that neither the identity coding
+// nor any of the content codings that can be applied to representations are
+// acceptable to the user agent.
+//
+// https://www.rfc-editor.org/rfc/rfc9110#section-12.5.3
+var errNoAcceptableContentCoding = errors.New("no acceptable content coding")
+
+// errThresholdReached indicates that the compression threshold was reached
+// while serializing a representation.
+var errThresholdReached = errors.New("compression threshold reached")
+
+// reachesThreshold determines if the content length of the provided
+// representation reaches the compression threshold. Representations unable to
+// hint at their content length that can be streamed are only serialized until
+// the threshold is reached, rather than in their entirety.
+func (n Negotiator) reachesThreshold(rep representation.Representation) (bool, error) {
+	if n.compressionThreshold <= 0 {
+		return true, nil
+	}
+	if r, ok := rep.(representation.RankedRepresentation); ok {
+		rep = r.Representation
+	}
+	if h, ok := rep.(representation.ContentLengthHinter); ok {
+		if length, known := h.ContentLengthHint(); known {
+			return length >= n.compressionThreshold, nil
+		}
+	}
+	wt, ok := rep.(io.WriterTo)
+	if !ok {
+		b, err := rep.Bytes()
+		if err != nil {
+			return false, err
+		}
+		return len(b) >= n.compressionThreshold, nil
+	}
+	_, err := wt.WriteTo(&thresholdWriter{remaining: n.compressionThreshold})
+	if errors.Is(err, errThresholdReached) {
+		return true, nil
+	}
+	return false, err
+}
+
+// thresholdWriter discards the bytes written to it, failing once the
+// remaining number of bytes has been written.
+type thresholdWriter struct {
+	remaining int
+}
+
+// Write discards the provided bytes, failing with errThresholdReached once
+// the threshold has been reached.
+func (w *thresholdWriter) Write(b []byte) (int, error) {
+	if len(b) >= w.remaining {
+		n := w.remaining
+		w.remaining = 0
+		return n, errThresholdReached
+	}
+	w.remaining -= len(b)
+	return len(b), nil
+}
+
+// compressible determines if the provided representation is eligible for
+// dynamic compression, which requires the representation to have no content
+// coding and a media type that is compressible.
+func (n Negotiator) compressible(rep representation.Representation) bool {
+	for _, ce := range rep.ContentEncoding() {
+		if !strings.EqualFold(ce, "identity") {
+			return false
+		}
+	}
+	mediaType := strings.ToLower(strings.TrimSpace(strings.Split(rep.ContentType(), ";")[0]))
+	for _, t := range n.compressibleTypes {
+		t = strings.ToLower(t)
+		if t == mediaType || t == "*/*" {
+			return true
+		}
+		if strings.HasSuffix(t, "/*") && strings.HasPrefix(mediaType, strings.TrimSuffix(t, "*")) {
+			return true
+		}
+	}
+	return false
+}
+
+// bestContentCoding determines the content coding most acceptable to the
+// user agent amongst those that can be applied to representations, along with
+// an indication of whether the identity coding is acceptable. An empty string
+// is returned when no content coding is acceptable or more acceptable than
+// the identity coding.
+func bestContentCoding(acceptEncoding header.AcceptEncoding) (string, bool) {
+	quality := func(coding string) (header.QualityValue, bool) {
+		var (
+			qv       header.QualityValue
+			wildcard bool
+		)
+		for _, cr := range acceptEncoding.CodingRanges() {
+			if strings.EqualFold(cr.CodingRange(), coding) {
+				return cr.QualityValue(), true
+			}
+			if cr.IsWildcard() {
+				qv, wildcard = cr.QualityValue(), true
+			}
+		}
+		return qv, wildcard
+	}
+	preference := func(coding string) int {
+		for idx, c := range contentCodingPreferences {
+			if strings.EqualFold(c, coding) {
+				return idx
+			}
+		}
+		return len(contentCodingPreferences)
+	}
+
+	var (
+		best        string
+		bestQuality header.QualityValue
+	)
+	for _, coding := range representation.ContentCodings() {
+		qv, ok := quality(coding)
+		if !ok || qv.Equals(header.QualityValueMinimum) {
+			continue
+		}
+		if len(best) == 0 || qv.GreaterThan(bestQuality) ||
+			(qv.Equals(bestQuality) && preference(coding) < preference(best)) {
+			best, bestQuality = coding, qv
+		}
+	}
+
+	// the identity coding is always acceptable unless stated otherwise.
+	identity, ok := quality("identity")
+	if !ok {
+		identity = header.QualityValueMaximum
+	}
+	acceptable := identity.GreaterThan(header.QualityValueMinimum)
+	if len(best) == 0 || identity.GreaterThan(bestQuality) {
+		return "", acceptable
+	}
+	return best, acceptable
+}
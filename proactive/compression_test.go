@@ -0,0 +1,318 @@
+package proactive_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/freerware/negotiator"
+	"github.com/freerware/negotiator/proactive"
+	"github.com/freerware/negotiator/representation"
+	"github.com/stretchr/testify/suite"
+	"github.com/uber-go/tally"
+	"go.uber.org/zap"
+)
+
+type document struct {
+	Body string `json:"body" xml:"body" yaml:"body"`
+}
+
+type CompressionTestSuite struct {
+	suite.Suite
+
+	// system under test.
+	sut negotiator.Negotiator
+
+	// fixtures.
+	large representation.Representation
+	small representation.Representation
+}
+
+func TestCompressionTestSuite(t *testing.T) {
+	suite.Run(t, new(CompressionTestSuite))
+}
+
+func (s *CompressionTestSuite) SetupTest() {
+	s.sut = proactive.New(
+		proactive.EnableCompression(),
+		proactive.Scope(tally.NoopScope),
+		proactive.Logger(zap.NewNop()),
+	)
+	large, err := representation.Of(
+		document{Body: strings.Repeat("negotiate ", 256)},
+		representation.MediaTypes("application/json"),
+		representation.Language("en-US"),
+	)
+	s.Require().NoError(err)
+	small, err := representation.Of(
+		document{Body: "negotiate"},
+		representation.MediaTypes("application/json"),
+		representation.Language("en-US"),
+	)
+	s.Require().NoError(err)
+	s.large, s.small = large[0], small[0]
+}
+
+func (s CompressionTestSuite) TestCompression_ContentCoding() {
+	tests := []struct {
+		name           string
+		acceptEncoding []string
+		coding         string
+	}{
+		{"Gzip", []string{"gzip"}, "gzip"},
+		{"Deflate", []string{"deflate"}, "deflate"},
+		{"HighestQuality", []string{"gzip;q=0.5", "deflate;q=0.8", "identity;q=0.1"}, "deflate"},
+		{"ImplicitIdentity", []string{"gzip;q=0.5", "deflate;q=0.8"}, ""},
+		{"PreferGzipOnTie", []string{"deflate", "gzip"}, "gzip"},
+		{"Wildcard", []string{"*"}, "gzip"},
+		{"WildcardExcluded", []string{"*", "gzip;q=0"}, "deflate"},
+		{"IdentityPreferred", []string{"identity", "gzip;q=0.5"}, ""},
+		{"IdentityTied", []string{"identity;q=0.5", "gzip;q=0.5"}, "gzip"},
+		{"Empty", nil, ""},
+	}
+	for _, test := range tests {
+		s.Run(test.name, func() {
+			// arrange.
+			request := httptest.NewRequest("GET", "http://freer.ddns.net/thing", nil)
+			for _, ae := range test.acceptEncoding {
+				request.Header.Add("Accept-Encoding", ae)
+			}
+
+			// action.
+			d, err := s.sut.(negotiator.Decider).Decide(request, s.large)
+
+			// assert.
+			s.Require().NoError(err)
+			s.Equal(negotiator.OutcomeAcceptable, d.Outcome)
+			s.Equal(test.coding, d.Header.Get("Content-Encoding"))
+		})
+	}
+}
+
+func (s CompressionTestSuite) TestCompression_Negotiate() {
+	// arrange.
+	request := httptest.NewRequest("GET", "http://freer.ddns.net/thing", nil)
+	request.Header.Add("Accept-Encoding", "gzip")
+	responseWriter := httptest.NewRecorder()
+	ctx := negotiator.NegotiationContext{Request: request, ResponseWriter: responseWriter}
+	expected, err := s.large.Bytes()
+	s.Require().NoError(err)
+
+	// action.
+	err = s.sut.Negotiate(ctx, s.large)
+
+	// assert.
+	s.Require().NoError(err)
+	response := responseWriter.Result()
+	s.Equal("gzip", response.Header.Get("Content-Encoding"))
+	s.Contains(response.Header.Get("Vary"), "Accept-Encoding")
+	reader, err := gzip.NewReader(response.Body)
+	s.Require().NoError(err)
+	actual, err := io.ReadAll(reader)
+	s.Require().NoError(err)
+	s.Equal(string(bytes.TrimSpace(expected)), string(bytes.TrimSpace(actual)))
+}
+
+func (s CompressionTestSuite) TestCompression_BelowThreshold() {
+	// arrange.
+	request := httptest.NewRequest("GET", "http://freer.ddns.net/thing", nil)
+	request.Header.Add("Accept-Encoding", "gzip")
+
+	// action.
+	d, err := s.sut.(negotiator.Decider).Decide(request, s.small)
+
+	// assert.
+	s.Require().NoError(err)
+	s.Empty(d.Header.Get("Content-Encoding"))
+	s.Equal(s.small, d.Representation)
+}
+
+func (s CompressionTestSuite) TestCompression_IdentityRefused() {
+	tests := []struct {
+		name           string
+		acceptEncoding string
+	}{
+		{"Identity", "identity;q=0, br"},
+		{"Wildcard", "*;q=0"},
+	}
+	for _, test := range tests {
+		s.Run(test.name, func() {
+			// arrange.
+			request := httptest.NewRequest("GET", "http://freer.ddns.net/thing", nil)
+			request.Header.Add("Accept-Encoding", test.acceptEncoding)
+
+			// action.
+			d, err := s.sut.(negotiator.Decider).Decide(request, s.large)
+
+			// assert.
+			s.Require().NoError(err)
+			s.Equal(negotiator.OutcomeNotAcceptable, d.Outcome)
+			s.Equal(http.StatusNotAcceptable, d.StatusCode)
+			s.Nil(d.Representation)
+		})
+	}
+}
+
+func (s CompressionTestSuite) TestCompression_IdentityRefused_BelowThreshold() {
+	// arrange.
+	request := httptest.NewRequest("GET", "http://freer.ddns.net/thing", nil)
+	request.Header.Add("Accept-Encoding", "identity;q=0, gzip")
+
+	// action.
+	d, err := s.sut.(negotiator.Decider).Decide(request, s.small)
+
+	// assert.
+	s.Require().NoError(err)
+	s.Equal(negotiator.OutcomeAcceptable, d.Outcome)
+	s.Equal("gzip", d.Header.Get("Content-Encoding"))
+}
+
+func (s CompressionTestSuite) TestCompression_Threshold() {
+	// arrange.
+	sut := proactive.New(
+		proactive.EnableCompression(),
+		proactive.CompressionThreshold(0),
+		proactive.Scope(tally.NoopScope),
+		proactive.Logger(zap.NewNop()),
+	)
+	request := httptest.NewRequest("GET", "http://freer.ddns.net/thing", nil)
+	request.Header.Add("Accept-Encoding", "gzip")
+
+	// action.
+	d, err := sut.Decide(request, s.small)
+
+	// assert.
+	s.Require().NoError(err)
+	s.Equal("gzip", d.Header.Get("Content-Encoding"))
+}
+
+func (s CompressionTestSuite) TestCompression_Threshold_Stream() {
+	tests := []struct {
+		name   string
+		size   int
+		coding string
+	}{
+		{"Below", 1000, ""},
+		{"Reached", 1024, "gzip"},
+		{"Large", 1 << 20, "gzip"},
+	}
+	for _, test := range tests {
+		s.Run(test.name, func() {
+			// arrange.
+			rep := &stream{size: test.size}
+			rep.SetContentType("application/json")
+			rep.SetContentLanguage("en-US")
+			rep.SetSourceQuality(1.0)
+			request := httptest.NewRequest("GET", "http://freer.ddns.net/thing", nil)
+			request.Header.Add("Accept-Encoding", "gzip")
+
+			// action.
+			d, err := s.sut.(negotiator.Decider).Decide(request, rep)
+
+			// assert.
+			s.Require().NoError(err)
+			s.Equal(test.coding, d.Header.Get("Content-Encoding"))
+			s.LessOrEqual(rep.written, 1024+len(chunk))
+			s.Zero(rep.serialized)
+		})
+	}
+}
+
+func (s CompressionTestSuite) TestCompression_Threshold_Hint() {
+	// arrange.
+	invocations := 0
+	rep := representation.NewLazy(func() (document, error) {
+		invocations++
+		return document{Body: "negotiate"}, nil
+	})
+	rep.SetContentType("application/json")
+	rep.SetContentLanguage("en-US")
+	rep.SetSourceQuality(1.0)
+	rep.SetContentLength(4096)
+	request := httptest.NewRequest("GET", "http://freer.ddns.net/thing", nil)
+	request.Header.Add("Accept-Encoding", "gzip")
+
+	// action.
+	d, err := s.sut.(negotiator.Decider).Decide(request, rep)
+
+	// assert.
+	s.Require().NoError(err)
+	s.Equal("gzip", d.Header.Get("Content-Encoding"))
+	s.Zero(invocations)
+}
+
+func (s CompressionTestSuite) TestCompression_CompressibleTypes() {
+	// arrange.
+	sut := proactive.New(
+		proactive.EnableCompression(),
+		proactive.CompressibleTypes("text/*"),
+		proactive.Scope(tally.NoopScope),
+		proactive.Logger(zap.NewNop()),
+	)
+	request := httptest.NewRequest("GET", "http://freer.ddns.net/thing", nil)
+	request.Header.Add("Accept-Encoding", "gzip")
+
+	// action.
+	d, err := sut.Decide(request, s.large)
+
+	// assert.
+	s.Require().NoError(err)
+	s.Empty(d.Header.Get("Content-Encoding"))
+}
+
+func (s CompressionTestSuite) TestCompression_Disabled() {
+	// arrange.
+	sut := proactive.New(
+		proactive.Scope(tally.NoopScope),
+		proactive.Logger(zap.NewNop()),
+	)
+	request := httptest.NewRequest("GET", "http://freer.ddns.net/thing", nil)
+	request.Header.Add("Accept-Encoding", "gzip")
+
+	// action.
+	d, err := sut.Decide(request, s.large)
+
+	// assert.
+	s.Require().NoError(err)
+	s.Empty(d.Header.Get("Content-Encoding"))
+}
+
+// chunk is the content streamed by stream representations.
+var chunk = []byte(strings.Repeat("x", 64))
+
+// stream represents a representation that is streamed in chunks, recording
+// how much of it has been serialized.
+type stream struct {
+	representation.Base
+
+	size       int
+	written    int
+	serialized int
+}
+
+func (r *stream) Bytes() ([]byte, error) {
+	r.serialized++
+	return bytes.Repeat([]byte("x"), r.size), nil
+}
+
+func (r *stream) WriteTo(w io.Writer) (int64, error) {
+	var total int64
+	for remaining := r.size; remaining > 0; remaining -= len(chunk) {
+		c := chunk[:min(len(chunk), remaining)]
+		n, err := w.Write(c)
+		total += int64(n)
+		r.written += len(c)
+		if err != nil {
+			return total, err
+		}
+	}
+	return total, nil
+}
+
+func (r *stream) FromBytes([]byte) error {
+	return nil
+}
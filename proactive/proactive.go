@@ -16,12 +16,11 @@
 package proactive
 
 import (
+	"errors"
 	"net/http"
-	"strconv"
-	"strings"
 
 	"github.com/freerware/negotiator"
-	"github.com/freerware/negotiator/internal/header"
+	"github.com/freerware/negotiator/header"
 	"github.com/freerware/negotiator/representation"
 	"github.com/uber-go/tally"
 	"go.uber.org/zap"
@@ -98,10 +97,17 @@ type Negotiator struct {
 	strictAccept                     bool
 	strictAcceptLanguage             bool
 	strictAcceptCharset              bool
+	strictAcceptFeatures             bool
 	notAcceptableRepresentation      bool
 	defaultRepresentationConstructor representation.ListConstructor
 	representationConstructors       []representation.ListConstructor
 	chooser                          representation.Chooser
+	compression                      bool
+	compressionThreshold             int
+	compressibleTypes                []string
+	headerPolicy                     negotiator.HeaderPolicy
+	headerCache                      *header.Cache
+	vary                             header.Vary
 	logger                           *zap.Logger
 	scope                            tally.Scope
 }
@@ -117,6 +123,8 @@ func New(options ...Option) Negotiator {
 		NotAcceptableRepresentation:      true,
 		DefaultRepresentationConstructor: jsonList,
 		Chooser:                          ApacheHTTPD(),
+		CompressionThreshold:             defaultCompressionThreshold,
+		CompressibleTypes:                defaultCompressibleTypes,
 		Logger:                           zap.NewNop(),
 		Scope:                            tally.NoopScope,
 		RepresentationConstructors: []representation.ListConstructor{
@@ -133,22 +141,71 @@ func New(options ...Option) Negotiator {
 		strictAccept:                     o.StrictAccept,
 		strictAcceptLanguage:             o.StrictAcceptLanguage,
 		strictAcceptCharset:              o.StrictAcceptCharset,
+		strictAcceptFeatures:             o.StrictAcceptFeatures,
 		notAcceptableRepresentation:      o.NotAcceptableRepresentation,
 		defaultRepresentationConstructor: o.DefaultRepresentationConstructor,
 		representationConstructors:       o.RepresentationConstructors,
 		chooser:                          o.Chooser,
+		compression:                      o.Compression,
+		compressionThreshold:             o.CompressionThreshold,
+		compressibleTypes:                o.CompressibleTypes,
+		headerPolicy:                     o.HeaderPolicy,
+		vary:                             vary(o),
 		logger:                           o.Logger,
 		scope:                            o.Scope.Tagged(scopeTagProactive),
 	}
+	if o.HeaderCacheSize > 0 {
+		n.headerCache = header.NewCache(o.HeaderCacheSize, n.scope)
+	}
 	n.logger.Debug("negotiator configuration",
 		zap.String("type", "proactive"),
 		zap.Bool("strict-accept", n.strictAccept),
 		zap.Bool("strict-accept-language", n.strictAcceptLanguage),
 		zap.Bool("strict-accept-charset", n.strictAcceptCharset),
-		zap.Bool("not-acceptable-representation", n.notAcceptableRepresentation))
+		zap.Bool("strict-accept-features", n.strictAcceptFeatures),
+		zap.Bool("not-acceptable-representation", n.notAcceptableRepresentation),
+		zap.Bool("compression", n.compression),
+		zap.Stringer("header-policy", n.headerPolicy),
+		zap.Int("header-cache-size", o.HeaderCacheSize),
+		zap.String("vary", n.vary.ValuesAsString()))
 	return n
 }
 
+// sanitize disregards the malformed request headers according to the header
+// policy of the negotiator.
+func (n Negotiator) sanitize(r *http.Request) *http.Request {
+	r, ignored := header.Sanitize(r, n.headerPolicy)
+	for _, err := range ignored {
+		n.logger.Info("ignored invalid header", zap.Error(err))
+	}
+	return r
+}
+
+// vary determines the request headers that influence the responses produced
+// by a negotiator configured with the provided options.
+func vary(o Options) header.Vary {
+	v := header.EmptyVary
+	for _, d := range representation.DimensionsOf(o.Chooser) {
+		v = v.Add(d.String())
+	}
+	if o.StrictAccept {
+		v = v.Add(representation.DimensionMediaType.String())
+	}
+	if o.StrictAcceptLanguage {
+		v = v.Add(representation.DimensionLanguage.String())
+	}
+	if o.StrictAcceptCharset {
+		v = v.Add(representation.DimensionCharset.String())
+	}
+	if o.StrictAcceptFeatures {
+		v = v.Add(representation.DimensionFeatures.String())
+	}
+	if o.Compression {
+		v = v.Add(representation.DimensionEncoding.String())
+	}
+	return v
+}
+
 // Negotiate performs proactive (server-driven) content negotiation with the
 // representations provided.
 func (n Negotiator) Negotiate(
@@ -161,149 +218,202 @@ func (n Negotiator) Negotiate(
 		}
 	}()
 
+	var d negotiator.Decision
+	if d, err = n.decide(ctx.Request, reps...); err != nil {
+		return err
+	}
+	if ctx.IsCreation && d.Outcome == negotiator.OutcomeAcceptable {
+		d.StatusCode = http.StatusCreated
+	}
+	_, err = d.WriteTo(ctx.ResponseWriter)
+	return err
+}
+
+// Decide performs proactive (server-driven) content negotiation with the
+// representations provided, returning the decision made without responding
+// to the user agent.
+func (n Negotiator) Decide(
+	r *http.Request, reps ...representation.Representation,
+) (d negotiator.Decision, err error) {
+	defer n.scope.Timer(scopeNameProactiveTimer).Start().Stop()
+	defer func() {
+		if err != nil {
+			n.scope.Counter(scopeNameProactiveErrorCounter).Inc(1)
+		}
+	}()
+	return n.decide(r, reps...)
+}
+
+// decide determines the outcome of proactive (server-driven) content
+// negotiation with the representations provided.
+func (n Negotiator) decide(
+	r *http.Request, reps ...representation.Representation,
+) (d negotiator.Decision, err error) {
 	if len(reps) == 0 {
 		status := http.StatusNoContent
-		ctx.ResponseWriter.WriteHeader(status)
 		n.logger.Info("no representations to negotiate", zap.Int("status", status))
 		n.scope.Counter(scopeNameProactiveNoContentCounter).Inc(1)
-		return err
+		d = negotiator.Decision{
+			Outcome:    negotiator.OutcomeNoContent,
+			StatusCode: status,
+			Header:     make(http.Header),
+		}
+		return d, err
 	}
 
+	r = n.sanitize(r)
+	if n.headerCache != nil {
+		// share the parsed headers with the algorithm.
+		r = r.WithContext(header.WithCache(r.Context(), n.headerCache))
+	}
 	var (
 		accept         = header.DefaultAccept
 		acceptLanguage = header.DefaultAcceptLanguage
 		acceptCharset  = header.DefaultAcceptCharset
+		acceptFeatures = header.DefaultAcceptFeatures
 		ac, alc, acc   = 0, 0, 0
+		afc            = 0
 		hasHeader      bool
 		headerValues   []string
 	)
-	if headerValues, hasHeader = ctx.Request.Header["Accept"]; hasHeader {
-		if accept, err = header.NewAccept(headerValues); err != nil {
-			return err
+	if headerValues, hasHeader = r.Header["Accept"]; hasHeader {
+		if accept, err = n.headerCache.Accept(headerValues); err != nil {
+			return d, err
 		}
 	}
-	if headerValues, hasHeader = ctx.Request.Header["Accept-Language"]; hasHeader {
-		if acceptLanguage, err = header.NewAcceptLanguage(headerValues); err != nil {
-			return err
+	if headerValues, hasHeader = r.Header["Accept-Language"]; hasHeader {
+		if acceptLanguage, err = n.headerCache.AcceptLanguage(headerValues); err != nil {
+			return d, err
 		}
 	}
-	if headerValues, hasHeader = ctx.Request.Header["Accept-Charset"]; hasHeader {
-		if acceptCharset, err = header.NewAcceptCharset(headerValues); err != nil {
-			return err
+	if headerValues, hasHeader = r.Header["Accept-Charset"]; hasHeader {
+		if acceptCharset, err = n.headerCache.AcceptCharset(headerValues); err != nil {
+			return d, err
 		}
 	}
-	for _, r := range reps {
+	if headerValues, hasHeader = r.Header["Accept-Features"]; hasHeader && n.strictAcceptFeatures {
+		if acceptFeatures, err = n.headerCache.AcceptFeatures(headerValues); err != nil {
+			return d, err
+		}
+	}
+	for _, rep := range reps {
 		var c bool
-		if c, err = accept.Compatible(r.ContentType()); err != nil {
-			return err
+		if c, err = accept.Compatible(rep.ContentType()); err != nil {
+			return d, err
 		} else if !c {
 			ac++
 		}
 
-		if c, err = acceptLanguage.Compatible(r.ContentLanguage()); err != nil {
-			return err
+		if c, err = acceptLanguage.Compatible(rep.ContentLanguage()); err != nil {
+			return d, err
 		} else if !c {
 			alc++
 		}
 
-		if c, err = acceptCharset.Compatible(r.ContentCharset()); err != nil {
-			return err
+		if c, err = acceptCharset.Compatible(rep.ContentCharset()); err != nil {
+			return d, err
 		} else if !c {
 			acc++
 		}
+
+		// feature lists are only validated in strict mode.
+		if n.strictAcceptFeatures {
+			var qf float32
+			if qf, err = acceptFeaturesQuality(rep, acceptFeatures); err != nil {
+				return d, err
+			} else if qf == 0 {
+				afc++
+			}
+		}
 	}
 	if len(reps) == ac && n.strictAccept {
 		n.logger.Debug("failed strict mode for Accept header")
-		return n.notAcceptable(ctx, reps...)
+		return n.notAcceptable(r, reps...)
 	}
 	if len(reps) == alc && n.strictAcceptLanguage {
 		n.logger.Debug("failed strict mode for Accept-Language header")
-		return n.notAcceptable(ctx, reps...)
+		return n.notAcceptable(r, reps...)
 	}
 	if len(reps) == acc && n.strictAcceptCharset {
 		n.logger.Debug("failed strict mode for Accept-Charset header")
-		return n.notAcceptable(ctx, reps...)
+		return n.notAcceptable(r, reps...)
+	}
+	if len(reps) == afc && n.strictAcceptFeatures {
+		n.logger.Debug("failed strict mode for Accept-Features header")
+		return n.notAcceptable(r, reps...)
 	}
 
 	// choose 'best' representation.
 	var rep representation.Representation
-	if rep, err = n.chooser.Choose(ctx.Request, reps...); err != nil {
-		return err
+	if rep, err = n.chooser.Choose(r, reps...); err != nil {
+		return d, err
 	}
 
 	if rep == nil {
-		return n.notAcceptable(ctx, reps...)
+		return n.notAcceptable(r, reps...)
 	}
-	return n.acceptable(ctx, rep)
+	if rep, err = n.compress(r, rep); errors.Is(err, errNoAcceptableContentCoding) {
+		n.logger.Debug("none of the content codings are acceptable")
+		return n.notAcceptable(r, reps...)
+	} else if err != nil {
+		return d, err
+	}
+	return n.acceptable(rep), nil
 }
 
-// acceptable is responsible for responding to the user agent with the
-// representation chosen by the server-side algorithm.
+// acceptable is responsible for deciding to respond to the user agent with
+// the representation chosen by the server-side algorithm.
 func (n Negotiator) acceptable(
-	ctx negotiator.NegotiationContext, rep representation.Representation,
-) (err error) {
-	defer func() {
-		if err == nil {
-			n.scope.Counter(scopeNameProactiveAcceptableCounter).Inc(1)
-		}
-	}()
-
-	// serialize.
-	var b []byte
-	if b, err = rep.Bytes(); err != nil {
-		return err
-	}
-
-	// respond.
+	rep representation.Representation,
+) negotiator.Decision {
 	var (
-		clen  = len(b)
-		ct    = rep.ContentType()
-		ce    = strings.Join(rep.ContentEncoding(), ",")
-		clang = rep.ContentLanguage()
-		cc    = rep.ContentCharset()
-		loc   = rep.ContentLocation()
+		h      = negotiator.ContentHeaders(rep)
+		loc    = rep.ContentLocation()
+		status = http.StatusOK
 	)
-	status := http.StatusOK
-	if ctx.IsCreation {
-		status = http.StatusCreated
-	}
-	ctx.ResponseWriter.Header().Add("Content-Location", (&loc).String())
-	ctx.ResponseWriter.Header().Add("Content-Length", strconv.Itoa(clen))
-	ctx.ResponseWriter.Header().Add("Content-Type", ct)
-	ctx.ResponseWriter.Header().Add("Content-Encoding", ce)
-	ctx.ResponseWriter.Header().Add("Content-Language", clang)
-	ctx.ResponseWriter.Header().Add("Content-Charset", cc)
-	ctx.ResponseWriter.WriteHeader(status)
-	if _, err = ctx.ResponseWriter.Write(b); err == nil {
-		n.logger.Info("acceptable",
-			zap.Int("content-length", clen),
-			zap.String("content-type", ct),
-			zap.String("content-encoding", ce),
-			zap.String("content-language", clang),
-			zap.String("content-charset", cc),
-			zap.String("content-location", (&loc).String()),
-			zap.Int("status", status))
+	h.Set("Content-Location", (&loc).String())
+	if !n.vary.IsEmpty() {
+		h.Set("Vary", n.vary.ValuesAsString())
+	}
+	n.logger.Info("acceptable",
+		zap.String("content-type", h.Get("Content-Type")),
+		zap.String("content-encoding", h.Get("Content-Encoding")),
+		zap.String("content-language", h.Get("Content-Language")),
+		zap.String("content-charset", h.Get("Content-Charset")),
+		zap.String("content-location", h.Get("Content-Location")),
+		zap.Int("status", status))
+	n.scope.Counter(scopeNameProactiveAcceptableCounter).Inc(1)
+	return negotiator.Decision{
+		Outcome:        negotiator.OutcomeAcceptable,
+		Representation: rep,
+		StatusCode:     status,
+		Header:         h,
 	}
-	return err
 }
 
-// notAcceptable is responsible for responding to the user agent with a
-// 406 HTTP status code, along with a representation describing the available
-// representations and their metadata.
+// notAcceptable is responsible for deciding to respond to the user agent
+// with a 406 HTTP status code, along with a representation describing the
+// available representations and their metadata.
 func (n Negotiator) notAcceptable(
-	ctx negotiator.NegotiationContext, reps ...representation.Representation,
-) (err error) {
+	r *http.Request, reps ...representation.Representation,
+) (d negotiator.Decision, err error) {
 	defer func() {
 		if err == nil {
 			n.scope.Counter(scopeNameProactiveNotAcceptableCounter).Inc(1)
 		}
 	}()
 
+	d = negotiator.Decision{
+		Outcome:    negotiator.OutcomeNotAcceptable,
+		StatusCode: http.StatusNotAcceptable,
+		Header:     make(http.Header),
+	}
+	if !n.vary.IsEmpty() {
+		d.Header.Set("Vary", n.vary.ValuesAsString())
+	}
 	if !n.notAcceptableRepresentation {
-		status := http.StatusNotAcceptable
-		ctx.ResponseWriter.WriteHeader(status)
-		n.logger.Info("not acceptable", zap.Int("status", status))
-		return nil
+		n.logger.Info("not acceptable", zap.Int("status", d.StatusCode))
+		return d, nil
 	}
 
 	// perform negotiation on representation.
@@ -314,8 +424,8 @@ func (n Negotiator) notAcceptable(
 	for _, c := range n.representationConstructors {
 		lists = append(lists, c(reps...))
 	}
-	if chosen, err = n.chooser.Choose(ctx.Request, lists...); err != nil {
-		return err
+	if chosen, err = n.chooser.Choose(r, lists...); err != nil {
+		return d, err
 	}
 	n.logger.Debug("completed choosing on not acceptable response",
 		zap.Int("representation-count", len(lists)))
@@ -326,35 +436,15 @@ func (n Negotiator) notAcceptable(
 		n.logger.Debug("chose default representation for not acceptable response")
 	}
 
-	// serialize.
-	var b []byte
-	if b, err = chosen.Bytes(); err != nil {
-		return err
+	for key, values := range negotiator.ContentHeaders(chosen) {
+		d.Header[key] = values
 	}
-
-	// respond.
-	var (
-		clen   = len(b)
-		ct     = chosen.ContentType()
-		ce     = strings.Join(chosen.ContentEncoding(), ",")
-		clang  = chosen.ContentLanguage()
-		cc     = chosen.ContentCharset()
-		status = http.StatusNotAcceptable
-	)
-	ctx.ResponseWriter.Header().Add("Content-Length", strconv.Itoa(clen))
-	ctx.ResponseWriter.Header().Add("Content-Type", ct)
-	ctx.ResponseWriter.Header().Add("Content-Encoding", ce)
-	ctx.ResponseWriter.Header().Add("Content-Language", clang)
-	ctx.ResponseWriter.Header().Add("Content-Charset", cc)
-	ctx.ResponseWriter.WriteHeader(status)
-	if _, err = ctx.ResponseWriter.Write(b); err == nil {
-		n.logger.Info("not acceptable",
-			zap.Int("content-length", clen),
-			zap.String("content-type", ct),
-			zap.String("content-encoding", ce),
-			zap.String("content-language", clang),
-			zap.String("content-charset", cc),
-			zap.Int("status", status))
-	}
-	return err
+	d.List = chosen
+	n.logger.Info("not acceptable",
+		zap.String("content-type", d.Header.Get("Content-Type")),
+		zap.String("content-encoding", d.Header.Get("Content-Encoding")),
+		zap.String("content-language", d.Header.Get("Content-Language")),
+		zap.String("content-charset", d.Header.Get("Content-Charset")),
+		zap.Int("status", d.StatusCode))
+	return d, nil
 }
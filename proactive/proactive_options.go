@@ -16,6 +16,7 @@
 package proactive
 
 import (
+	"github.com/freerware/negotiator"
 	"github.com/freerware/negotiator/representation"
 	"github.com/uber-go/tally"
 	"go.uber.org/zap"
@@ -27,10 +28,16 @@ type Options struct {
 	StrictAccept                     bool
 	StrictAcceptLanguage             bool
 	StrictAcceptCharset              bool
+	StrictAcceptFeatures             bool
 	NotAcceptableRepresentation      bool
 	DefaultRepresentationConstructor representation.ListConstructor
 	RepresentationConstructors       []representation.ListConstructor
 	Chooser                          representation.Chooser
+	Compression                      bool
+	CompressionThreshold             int
+	CompressibleTypes                []string
+	HeaderPolicy                     negotiator.HeaderPolicy
+	HeaderCacheSize                  int
 	Logger                           *zap.Logger
 	Scope                            tally.Scope
 }
@@ -78,6 +85,16 @@ var (
 			o.StrictAccept = false
 			o.StrictAcceptLanguage = false
 			o.StrictAcceptCharset = false
+			o.StrictAcceptFeatures = false
+		}
+	}
+
+	// StrictAcceptFeatures activates strict mode for the Accept-Features
+	// header, meaning that a 406 HTTP status code is returned if none of the
+	// representations have features that are acceptable.
+	StrictAcceptFeatures = func() Option {
+		return func(o *Options) {
+			o.StrictAcceptFeatures = true
 		}
 	}
 
@@ -115,6 +132,51 @@ var (
 		}
 	}
 
+	// EnableCompression activates dynamic compression, where the content
+	// coding of the chosen representation is chosen separately based on the
+	// Accept-Encoding header and applied as the representation is written.
+	// Representations that already have a content coding are not compressed.
+	EnableCompression = func() Option {
+		return func(o *Options) {
+			o.Compression = true
+		}
+	}
+
+	// CompressionThreshold defines the minimum content length, in bytes, of
+	// representations to compress when dynamic compression is enabled.
+	CompressionThreshold = func(bytes int) Option {
+		return func(o *Options) {
+			o.CompressionThreshold = bytes
+		}
+	}
+
+	// CompressibleTypes defines the media types, such as "application/json"
+	// or "text/*", of representations to compress when dynamic compression
+	// is enabled. Media types that are already compressed, such as images,
+	// should be omitted.
+	CompressibleTypes = func(mediaTypes ...string) Option {
+		return func(o *Options) {
+			o.CompressibleTypes = mediaTypes
+		}
+	}
+
+	// InvalidHeaders defines how malformed request headers are treated, which
+	// are rejected by default.
+	InvalidHeaders = func(policy negotiator.HeaderPolicy) Option {
+		return func(o *Options) {
+			o.HeaderPolicy = policy
+		}
+	}
+
+	// HeaderCache activates caching of parsed request headers, retaining at
+	// most the provided number of distinct header values so that identical
+	// headers sent across requests are parsed only once.
+	HeaderCache = func(size int) Option {
+		return func(o *Options) {
+			o.HeaderCacheSize = size
+		}
+	}
+
 	// Logger specifies the logger for the proactive negotiator.
 	Logger = func(l *zap.Logger) Option {
 		return func(o *Options) {
@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"testing/fstest"
 
 	"github.com/freerware/negotiator"
 	_representation "github.com/freerware/negotiator/internal/representation"
@@ -428,7 +429,7 @@ func (s ProactiveTestSuite) TestProactive_InvalidAcceptCharset() {
 	request.Header.Add("Accept", "application/json")
 	request.Header.Add("Accept-Encoding", "gzip")
 	request.Header.Add("Accept-Language", "en-US")
-	request.Header.Add("Accept-Charset", "")
+	request.Header.Add("Accept-Charset", "ascii;q=abc")
 	ctx := negotiator.NegotiationContext{Request: request, ResponseWriter: responseWriter}
 	v := _representation.NewBuilder().
 		WithLocation(*request.URL).
@@ -721,6 +722,45 @@ func (s ProactiveTestSuite) TestProactive_NotAcceptable_DefaultRepresentation()
 	s.Equal(xList.ContentType(), response.Header.Get("Content-Type"))
 }
 
+func (s ProactiveTestSuite) TestProactive_NotAcceptable_Problem() {
+	// arrange.
+	sut := proactive.New(
+		proactive.Representations(
+			representation.NotAcceptableProblem(representation.ProblemJSON),
+			representation.NotAcceptableProblem(representation.ProblemXML),
+		),
+		proactive.DefaultRepresentation(
+			representation.NotAcceptableProblem(representation.ProblemJSON),
+		),
+	)
+	request := httptest.NewRequest("GET", "http://freer.ddns.net/thing", nil)
+	request.Header.Add("Accept", "text/html")
+	request.Header.Add("Accept", representation.ProblemXML)
+	responseWriter := httptest.NewRecorder()
+	ctx := negotiator.NegotiationContext{Request: request, ResponseWriter: responseWriter}
+	v := _representation.NewBuilder().
+		WithLocation(*request.URL).
+		WithType("application/json").
+		WithLanguage("en-US").
+		WithCharset("ascii").
+		WithSourceQuality(1.0).
+		Build(test.RepresentationBuilderFunc)
+
+	// action.
+	err := sut.Negotiate(ctx, v)
+
+	// assert.
+	s.Require().NoError(err)
+	response := responseWriter.Result()
+	s.Equal(http.StatusNotAcceptable, response.StatusCode)
+	s.Equal(representation.ProblemXML, response.Header.Get("Content-Type"))
+	problem := representation.NewProblem(representation.ProblemXML, http.StatusOK)
+	s.Require().NoError(problem.FromBytes(responseWriter.Body.Bytes()))
+	s.Equal(http.StatusNotAcceptable, problem.Status)
+	s.Require().Len(problem.Variants, 1)
+	s.Equal("application/json", problem.Variants[0].ContentType)
+}
+
 func (s ProactiveTestSuite) TestProactive_NotAcceptable_ChooserError() {
 	// arrange.
 	_json, english, ascii, gzip := "application/json", "en-US", "ascii", "gzip"
@@ -910,9 +950,362 @@ func (s ProactiveTestSuite) TestProactive_IsCreation() {
 	s.Equal(_json, response.Header.Get("Content-Type"))
 }
 
+func (s ProactiveTestSuite) TestProactive_Vary() {
+	// arrange.
+	_json, english, ascii, gzip := "application/json", "en-US", "ascii", "gzip"
+	request := httptest.NewRequest("GET", "http://freer.ddns.net/thing", nil)
+	responseWriter := httptest.NewRecorder()
+	responseWriter.Header().Set("Vary", "Cookie")
+	request.Header.Add("Accept", _json)
+	ctx := negotiator.NegotiationContext{Request: request, ResponseWriter: responseWriter}
+	v := _representation.NewBuilder().
+		WithLocation(*request.URL).
+		WithType(_json).
+		WithLanguage(english).
+		WithEncoding(gzip).
+		WithCharset(ascii).
+		WithSourceQuality(1.0).
+		Build(test.RepresentationBuilderFunc)
+	variants := []representation.Representation{v}
+	s.chooser.
+		EXPECT().
+		Choose(ctx.Request, gomock.Any()).
+		Return(v, nil).
+		Times(1)
+
+	// action.
+	err := s.sut.Negotiate(ctx, variants...)
+
+	// assert.
+	s.Require().NoError(err)
+	response := responseWriter.Result()
+	s.Equal(
+		"Cookie, Accept, Accept-Language, Accept-Charset, Accept-Encoding",
+		response.Header.Get("Vary"),
+	)
+}
+
+func (s ProactiveTestSuite) TestProactive_InvalidHeader() {
+	// arrange.
+	request := httptest.NewRequest("GET", "http://freer.ddns.net/thing", nil)
+	request.Header.Add("Accept", "text/html;q=abc")
+	v := _representation.NewBuilder().
+		WithLocation(*request.URL).
+		WithType("application/json").
+		Build(test.RepresentationBuilderFunc)
+
+	// action.
+	_, err := s.sut.(negotiator.Decider).Decide(request, v)
+
+	// assert.
+	s.Require().ErrorIs(err, negotiator.ErrInvalidHeader)
+}
+
+func (s ProactiveTestSuite) TestProactive_InvalidHeaders() {
+	tests := []struct {
+		name    string
+		policy  negotiator.HeaderPolicy
+		accept  []string
+		outcome negotiator.Outcome
+		err     bool
+	}{
+		{"Reject", negotiator.RejectInvalidHeaders, []string{"text/html;q=abc", "application/xml"}, 0, true},
+		{"IgnoreHeader", negotiator.IgnoreInvalidHeaders, []string{"text/html;q=abc", "application/xml"}, negotiator.OutcomeAcceptable, false},
+		{"IgnoreElement", negotiator.IgnoreInvalidElements, []string{"text/html;q=abc", "application/xml"}, negotiator.OutcomeNotAcceptable, false},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			// arrange.
+			sut := proactive.New(proactive.InvalidHeaders(tt.policy))
+			request := httptest.NewRequest("GET", "http://freer.ddns.net/thing", nil)
+			request.Header["Accept"] = tt.accept
+			v := _representation.NewBuilder().
+				WithLocation(*request.URL).
+				WithType("application/json").
+				WithLanguage("en-US").
+				WithCharset("ascii").
+				WithSourceQuality(1.0).
+				Build(test.RepresentationBuilderFunc)
+
+			// action.
+			d, err := sut.Decide(request, v)
+
+			// assert.
+			if tt.err {
+				var headerErr *negotiator.HeaderError
+				s.Require().True(errors.As(err, &headerErr))
+				s.Equal("Accept", headerErr.Header)
+				s.Equal("text/html;q=abc", headerErr.Value)
+				return
+			}
+			s.Require().NoError(err)
+			s.Equal(tt.outcome, d.Outcome)
+		})
+	}
+}
+
+func (s ProactiveTestSuite) TestProactive_Decide() {
+	// arrange.
+	_json, english, ascii, gzip := "application/json", "en-US", "ascii", "gzip"
+	request := httptest.NewRequest("GET", "http://freer.ddns.net/thing", nil)
+	request.Header.Add("Accept", _json)
+	v := _representation.NewBuilder().
+		WithLocation(*request.URL).
+		WithType(_json).
+		WithLanguage(english).
+		WithEncoding(gzip).
+		WithCharset(ascii).
+		WithSourceQuality(1.0).
+		Build(test.RepresentationBuilderFunc)
+	variants := []representation.Representation{v}
+	s.chooser.
+		EXPECT().
+		Choose(request, gomock.Any()).
+		Return(v, nil).
+		Times(1)
+
+	// action.
+	d, err := s.sut.(negotiator.Decider).Decide(request, variants...)
+
+	// assert.
+	s.Require().NoError(err)
+	s.Equal(negotiator.OutcomeAcceptable, d.Outcome)
+	s.Equal(http.StatusOK, d.StatusCode)
+	s.Equal(v, d.Representation)
+	s.Nil(d.List)
+	s.Equal(_json, d.Header.Get("Content-Type"))
+	s.Equal(gzip, d.Header.Get("Content-Encoding"))
+	s.Equal(english, d.Header.Get("Content-Language"))
+	s.Equal(ascii, d.Header.Get("Content-Charset"))
+	s.Equal(request.URL.String(), d.Header.Get("Content-Location"))
+	s.Equal(
+		"Accept, Accept-Language, Accept-Charset, Accept-Encoding",
+		d.Header.Get("Vary"),
+	)
+}
+
+func (s ProactiveTestSuite) TestProactive_Decide_NotAcceptable() {
+	// arrange.
+	_json, english, ascii := "application/json", "en-US", "ascii"
+	request := httptest.NewRequest("GET", "http://freer.ddns.net/thing", nil)
+	request.Header.Add("Accept", "application/xml")
+	v := _representation.NewBuilder().
+		WithLocation(*request.URL).
+		WithType(_json).
+		WithLanguage(english).
+		WithCharset(ascii).
+		WithSourceQuality(1.0).
+		Build(test.RepresentationBuilderFunc)
+	variants := []representation.Representation{v}
+	s.chooser.
+		EXPECT().
+		Choose(request, gomock.Any()).
+		Return(nil, nil).
+		Times(1)
+
+	// action.
+	d, err := s.sut.(negotiator.Decider).Decide(request, variants...)
+
+	// assert.
+	s.Require().NoError(err)
+	s.Equal(negotiator.OutcomeNotAcceptable, d.Outcome)
+	s.Equal(http.StatusNotAcceptable, d.StatusCode)
+	s.Nil(d.Representation)
+	s.Require().NotNil(d.List)
+	s.Equal(d.List, d.Body())
+	s.Equal(_json, d.Header.Get("Content-Type"))
+}
+
+func (s ProactiveTestSuite) TestProactive_HeaderCache() {
+	// arrange.
+	scope := tally.NewTestScope("", nil)
+	sut := proactive.New(proactive.HeaderCache(16), proactive.Scope(scope))
+	v := _representation.NewBuilder().
+		WithType("application/json").
+		WithLanguage("en-US").
+		WithCharset("ascii").
+		WithSourceQuality(1.0).
+		Build(test.RepresentationBuilderFunc)
+
+	for i := 0; i < 2; i++ {
+		request := httptest.NewRequest("GET", "http://freer.ddns.net/thing", nil)
+		request.Header.Add("Accept", "application/json, */*;q=0.1")
+		request.Header.Add("Accept-Language", "en-US, en;q=0.5")
+		request.Header.Add("Accept-Charset", "ascii")
+
+		// action.
+		d, err := sut.Decide(request, v)
+
+		// assert.
+		s.Require().NoError(err)
+		s.Equal(negotiator.OutcomeAcceptable, d.Outcome)
+	}
+
+	// assert.
+	counters := scope.Snapshot().Counters()
+	// the strict mode check parses the headers once, which the algorithm and
+	// subsequent requests reuse.
+	s.Equal(int64(5), counters["negotiate.header_cache.miss+negotiator=proactive"].Value())
+	s.Equal(int64(11), counters["negotiate.header_cache.hit+negotiator=proactive"].Value())
+}
+
 func (s *ProactiveTestSuite) TearDownTest() {
 	s.mc.Finish()
 	s.mc = nil
 	s.sut = nil
 	s.chooser = nil
 }
+
+func (s ProactiveTestSuite) TestProactive_StrictAcceptFeatures() {
+	// arrange.
+	v := _representation.NewBuilder().
+		WithType("text/html").
+		WithLanguage("en-US").
+		WithCharset("ascii").
+		WithSourceQuality(1.0).
+		WithFeature("tables").
+		Build(test.RepresentationBuilderFunc)
+	tests := []struct {
+		name           string
+		options        []proactive.Option
+		acceptFeatures []string
+		outcome        negotiator.Outcome
+		vary           string
+	}{
+		{
+			"Acceptable",
+			[]proactive.Option{proactive.StrictAcceptFeatures()},
+			[]string{"tables"},
+			negotiator.OutcomeAcceptable,
+			"Accept, Accept-Language, Accept-Charset, Accept-Encoding, Accept-Features",
+		},
+		{
+			"NotAcceptable",
+			[]proactive.Option{proactive.StrictAcceptFeatures()},
+			[]string{"!tables"},
+			negotiator.OutcomeNotAcceptable,
+			"Accept, Accept-Language, Accept-Charset, Accept-Encoding, Accept-Features",
+		},
+		{
+			"MissingAcceptFeatures",
+			[]proactive.Option{proactive.StrictAcceptFeatures()},
+			nil,
+			negotiator.OutcomeAcceptable,
+			"Accept, Accept-Language, Accept-Charset, Accept-Encoding, Accept-Features",
+		},
+	}
+	for _, test := range tests {
+		s.Run(test.name, func() {
+			// arrange.
+			sut := proactive.New(test.options...)
+			request := httptest.NewRequest("GET", "http://freer.ddns.net/thing", nil)
+			request.Header["Accept-Features"] = test.acceptFeatures
+
+			// action.
+			d, err := sut.Decide(request, v)
+
+			// assert.
+			s.Require().NoError(err)
+			s.Equal(test.outcome, d.Outcome)
+			s.Equal(test.vary, d.Header.Get("Vary"))
+		})
+	}
+}
+
+func (s ProactiveTestSuite) TestProactive_InvalidContentFeatures() {
+	// arrange.
+	variant := func(qs float32, features ...string) representation.Representation {
+		b := _representation.NewBuilder().
+			WithType("text/html").
+			WithLanguage("en-US").
+			WithCharset("ascii").
+			WithSourceQuality(qs)
+		for _, f := range features {
+			b = b.WithFeature(f)
+		}
+		return b.Build(test.RepresentationBuilderFunc)
+	}
+	invalid, valid := variant(1.0, "tables;+abc"), variant(0.5)
+	tests := []struct {
+		name    string
+		options []proactive.Option
+		err     bool
+	}{
+		{"NonStrict", nil, false},
+		{"Strict", []proactive.Option{proactive.StrictAcceptFeatures()}, true},
+	}
+	for _, test := range tests {
+		s.Run(test.name, func() {
+			// arrange.
+			sut := proactive.New(test.options...)
+			request := httptest.NewRequest("GET", "http://freer.ddns.net/thing", nil)
+			request.Header.Set("Accept-Features", "tables")
+
+			// action.
+			d, err := sut.Decide(request, invalid, valid)
+
+			// assert.
+			if test.err {
+				s.Require().Error(err)
+				return
+			}
+			s.Require().NoError(err)
+			s.Equal(negotiator.OutcomeAcceptable, d.Outcome)
+			s.Equal(invalid, d.Representation)
+		})
+	}
+}
+
+func (s ProactiveTestSuite) TestProactive_TypeMap() {
+	// arrange.
+	fsys := fstest.MapFS{
+		"foo.var": {Data: []byte("URI: foo\n\n" +
+			"URI: foo.en.html\nContent-Type: text/html\nContent-Language: en\n\n" +
+			"URI: foo.fr.html\nContent-Type: text/html\nContent-Language: fr\n\n" +
+			"URI: foo.txt\nContent-Type: text/plain; qs=0.9\nContent-Language: en\n")},
+		"foo.en.html": {Data: []byte("<p>hello</p>")},
+		"foo.fr.html": {Data: []byte("<p>bonjour</p>")},
+		"foo.txt":     {Data: []byte("hello")},
+	}
+	variants, err := representation.ParseTypeMapFS(fsys, "foo.var")
+	s.Require().NoError(err)
+	sut := proactive.New(
+		proactive.Algorithm(proactive.ApacheHTTPD()),
+		proactive.DisableStrictMode(),
+		proactive.Scope(tally.NoopScope),
+		proactive.Logger(zap.NewNop()),
+	)
+	tests := []struct {
+		name           string
+		accept         string
+		acceptLanguage string
+		contentType    string
+		body           string
+	}{
+		{"SourceQuality", "text/html, text/plain", "", "text/html", "<p>hello</p>"},
+		{"Language", "text/html", "fr, en;q=0.5", "text/html", "<p>bonjour</p>"},
+		{"Type", "text/plain", "en", "text/plain", "hello"},
+	}
+	for _, test := range tests {
+		s.Run(test.name, func() {
+			// arrange.
+			request := httptest.NewRequest("GET", "http://freer.ddns.net/foo", nil)
+			request.Header.Set("Accept", test.accept)
+			if len(test.acceptLanguage) > 0 {
+				request.Header.Set("Accept-Language", test.acceptLanguage)
+			}
+			responseWriter := httptest.NewRecorder()
+			ctx := negotiator.NegotiationContext{Request: request, ResponseWriter: responseWriter}
+
+			// action.
+			err := sut.Negotiate(ctx, variants...)
+
+			// assert.
+			s.Require().NoError(err)
+			response := responseWriter.Result()
+			s.Equal(http.StatusOK, response.StatusCode)
+			s.Equal(test.contentType, response.Header.Get("Content-Type"))
+			s.Equal(test.body, responseWriter.Body.String())
+		})
+	}
+}
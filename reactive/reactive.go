@@ -17,7 +17,6 @@ package reactive
 
 import (
 	"net/http"
-	"strconv"
 	"strings"
 
 	"github.com/freerware/negotiator"
@@ -96,51 +95,62 @@ func (n Negotiator) Negotiate(
 	defer func() {
 		if err != nil {
 			n.scope.Counter(scopeNameReactiveErrorCounter).Inc(1)
-		} else {
-			n.scope.Counter(scopeNameReactiveMultipleChoicesCounter).Inc(1)
 		}
 	}()
 
+	d := n.decide(reps...)
+	_, err = d.WriteTo(ctx.ResponseWriter)
+	return err
+}
+
+// Decide performs reactive (agent-driven) content negotiation with the
+// representations provided, returning the decision made without responding
+// to the user agent.
+func (n Negotiator) Decide(
+	r *http.Request, reps ...representation.Representation,
+) (negotiator.Decision, error) {
+	defer n.scope.Timer(scopeNameReactiveTimer).Start().Stop()
+	return n.decide(reps...), nil
+}
+
+// decide determines the outcome of reactive (agent-driven) content
+// negotiation with the representations provided.
+func (n Negotiator) decide(
+	reps ...representation.Representation,
+) negotiator.Decision {
 	if len(reps) == 0 {
 		status := http.StatusNoContent
-		ctx.ResponseWriter.WriteHeader(status)
 		n.logger.Info("no representations to negotiate", zap.Int("status", status))
 		n.scope.Counter(scopeNameReactiveNoContentCounter).Inc(1)
-		return err
+		return negotiator.Decision{
+			Outcome:    negotiator.OutcomeNoContent,
+			StatusCode: status,
+			Header:     make(http.Header),
+		}
 	}
 
 	// construct representation.
 	rep := n.representationConstructor(reps...)
 
-	// serialize.
-	var b []byte
-	if b, err = rep.Bytes(); err != nil {
-		return err
-	}
-
-	// respond.
 	var (
-		clen   = len(b)
-		ct     = rep.ContentType()
-		ce     = strings.Join(rep.ContentEncoding(), ",")
-		clang  = rep.ContentLanguage()
-		cc     = rep.ContentCharset()
+		h      = make(http.Header)
 		status = http.StatusMultipleChoices
 	)
-	ctx.ResponseWriter.Header().Add("Content-Length", strconv.Itoa(clen))
-	ctx.ResponseWriter.Header().Add("Content-Type", ct)
-	ctx.ResponseWriter.Header().Add("Content-Encoding", ce)
-	ctx.ResponseWriter.Header().Add("Content-Language", clang)
-	ctx.ResponseWriter.Header().Add("Content-Charset", cc)
-	ctx.ResponseWriter.WriteHeader(status)
-	if _, err = ctx.ResponseWriter.Write(b); err == nil {
-		n.logger.Info("multiple choices",
-			zap.Int("content-length", clen),
-			zap.String("content-type", ct),
-			zap.String("content-encoding", ce),
-			zap.String("content-language", clang),
-			zap.String("content-charset", cc),
-			zap.Int("status", status))
+	h.Set("Content-Type", rep.ContentType())
+	h.Set("Content-Encoding", strings.Join(rep.ContentEncoding(), ","))
+	h.Set("Content-Language", rep.ContentLanguage())
+	h.Set("Content-Charset", rep.ContentCharset())
+	n.logger.Info("multiple choices",
+		zap.String("content-type", h.Get("Content-Type")),
+		zap.String("content-encoding", h.Get("Content-Encoding")),
+		zap.String("content-language", h.Get("Content-Language")),
+		zap.String("content-charset", h.Get("Content-Charset")),
+		zap.Int("status", status))
+	n.scope.Counter(scopeNameReactiveMultipleChoicesCounter).Inc(1)
+	return negotiator.Decision{
+		Outcome:    negotiator.OutcomeMultipleChoices,
+		List:       rep,
+		StatusCode: status,
+		Header:     h,
 	}
-	return err
 }
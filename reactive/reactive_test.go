@@ -93,6 +93,7 @@ func (s ReactiveTestSuite) TestReactive() {
 	s.Equal(http.StatusMultipleChoices, response.StatusCode)
 	s.Equal(expectedLen, responseWriter.Body.Len())
 	s.Equal(jList.ContentType(), response.Header.Get("Content-Type"))
+	s.Empty(response.Header.Values("Vary"))
 }
 
 func (s ReactiveTestSuite) TestReactive_ListConstructor() {
@@ -134,6 +135,46 @@ func (s ReactiveTestSuite) TestReactive_ListConstructor() {
 	s.Equal(jList.ContentType(), response.Header.Get("Content-Type"))
 }
 
+func (s ReactiveTestSuite) TestReactive_Decide() {
+	// arrange.
+	_json, english, ascii := "application/json", "en-US", "ascii"
+	request := httptest.NewRequest("GET", "http://freer.ddns.net/thing", nil)
+	v := _representation.NewBuilder().
+		WithLocation(*request.URL).
+		WithType(_json).
+		WithLanguage(english).
+		WithCharset(ascii).
+		WithSourceQuality(1.0).
+		Build(test.RepresentationBuilderFunc)
+	variants := []representation.Representation{v}
+
+	// action.
+	d, err := s.sut.(negotiator.Decider).Decide(request, variants...)
+
+	// assert.
+	s.Require().NoError(err)
+	s.Equal(negotiator.OutcomeMultipleChoices, d.Outcome)
+	s.Equal(http.StatusMultipleChoices, d.StatusCode)
+	s.Nil(d.Representation)
+	s.Require().NotNil(d.List)
+	s.Equal(jsonList(variants...), d.List)
+	s.Equal(_json, d.Header.Get("Content-Type"))
+}
+
+func (s ReactiveTestSuite) TestReactive_Decide_NoRepresentations() {
+	// arrange.
+	request := httptest.NewRequest("GET", "http://freer.ddns.net/thing", nil)
+
+	// action.
+	d, err := s.sut.(negotiator.Decider).Decide(request)
+
+	// assert.
+	s.Require().NoError(err)
+	s.Equal(negotiator.OutcomeNoContent, d.Outcome)
+	s.Equal(http.StatusNoContent, d.StatusCode)
+	s.Nil(d.Body())
+}
+
 func (s *ReactiveTestSuite) TearDownTest() {
 	s.sut = nil
 }
@@ -0,0 +1,69 @@
+/* Copyright 2020 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package representation
+
+// ContentDescriber is implemented by representations that are capable of
+// providing a textual description of themselves, such as within the
+// variant descriptions of the Alternates header.
+type ContentDescriber interface {
+	// ContentDescription provides the textual description of the
+	// representation, along with the language tag of the language it is
+	// written in, if any.
+	ContentDescription() (string, string)
+}
+
+// ExtensionAttributer is implemented by representations that are capable of
+// providing attributes beyond those defined for variant descriptions, such as
+// within the Alternates header.
+type ExtensionAttributer interface {
+	// ExtensionAttributes provides the extension attributes of the
+	// representation, keyed on their names.
+	ExtensionAttributes() map[string]string
+}
+
+// unwrap retrieves the representation wrapped by the provided
+// representation, if any.
+func unwrap(rep Representation) Representation {
+	for {
+		switch r := rep.(type) {
+		case RankedRepresentation:
+			rep = r.Representation
+		case Encoded:
+			rep = r.Unencoded()
+		default:
+			return rep
+		}
+	}
+}
+
+// ContentDescription determines the textual description of the provided
+// representation, along with the language tag of the language it is written
+// in, if any.
+func ContentDescription(rep Representation) (string, string) {
+	if d, ok := unwrap(rep).(ContentDescriber); ok {
+		return d.ContentDescription()
+	}
+	return "", ""
+}
+
+// ExtensionAttributes determines the extension attributes of the provided
+// representation, keyed on their names.
+func ExtensionAttributes(rep Representation) map[string]string {
+	if a, ok := unwrap(rep).(ExtensionAttributer); ok {
+		return a.ExtensionAttributes()
+	}
+	return nil
+}
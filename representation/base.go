@@ -63,6 +63,14 @@ var (
 		"text/html":        xml.Marshal,
 	}
 
+	defaultStreamMarshallers = map[string]StreamMarshaller{
+		"application/json": streamJSON,
+		"application/xml":  streamXML,
+		"application/yaml": streamYAML,
+		"text/yaml":        streamYAML,
+		"text/html":        streamXML,
+	}
+
 	defaultEncodingWriters = map[string]EncodingWriterConstructor{
 		"gzip":       newGzipWriter,
 		"x-gzip":     newGzipWriter,
@@ -74,17 +82,21 @@ var (
 
 // Base is the base representation.
 type Base struct {
-	encoding        []string
-	mediaType       string
-	charset         string
-	language        string
-	location        url.URL
-	sourceQuality   float32
-	features        []string
-	marshallers     map[string]Marshaller
-	unmarshallers   map[string]Unmarshaller
-	encodingReaders map[string]EncodingReaderConstructor
-	encodingWriters map[string]EncodingWriterConstructor
+	encoding          []string
+	mediaType         string
+	charset           string
+	language          string
+	location          url.URL
+	sourceQuality     float32
+	features          []string
+	description       string
+	descriptionLang   string
+	extensions        map[string]string
+	marshallers       map[string]Marshaller
+	streamMarshallers map[string]StreamMarshaller
+	unmarshallers     map[string]Unmarshaller
+	encodingReaders   map[string]EncodingReaderConstructor
+	encodingWriters   map[string]EncodingWriterConstructor
 }
 
 // ContentType retrieves the content type of the representation.
@@ -129,11 +141,50 @@ func (r Base) SourceQuality() float32 { return r.sourceQuality }
 // SetSourceQuality modifies the source quality of the representation.
 func (r *Base) SetSourceQuality(sq float32) { r.sourceQuality = sq }
 
+// ContentDescription retrieves the textual description of the
+// representation, along with the language tag of the language it is written
+// in.
+func (r Base) ContentDescription() (string, string) { return r.description, r.descriptionLang }
+
+// SetContentDescription modifies the textual description of the
+// representation, along with the language tag of the language it is written
+// in, which may be empty.
+func (r *Base) SetContentDescription(text, language string) {
+	r.description, r.descriptionLang = text, language
+}
+
+// ExtensionAttributes retrieves the extension attributes of the
+// representation.
+func (r Base) ExtensionAttributes() map[string]string {
+	extensions := make(map[string]string, len(r.extensions))
+	for name, value := range r.extensions {
+		extensions[name] = value
+	}
+	return extensions
+}
+
+// SetExtensionAttribute modifies the extension attribute with the provided
+// name.
+func (r *Base) SetExtensionAttribute(name, value string) {
+	extensions := make(map[string]string, len(r.extensions)+1)
+	for n, v := range r.extensions {
+		extensions[n] = v
+	}
+	extensions[name] = value
+	r.extensions = extensions
+}
+
 // SetMarshallers modifies the marshallers for the representation.
 func (r *Base) SetMarshallers(m map[string]Marshaller) {
 	r.marshallers = m
 }
 
+// SetStreamMarshallers modifies the stream marshallers for the
+// representation.
+func (r *Base) SetStreamMarshallers(m map[string]StreamMarshaller) {
+	r.streamMarshallers = m
+}
+
 // SetUnmarshallers modifies the unmarshallers for the representation.
 func (r *Base) SetUnmarshallers(u map[string]Unmarshaller) {
 	r.unmarshallers = u
@@ -178,33 +229,96 @@ func (r Base) Bytes(out interface{}) ([]byte, error) {
 
 func (r *Base) encode(b []byte) (bb []byte, err error) {
 	var (
-		buf                bytes.Buffer
-		writer             io.WriteCloser = &closeableBuffer{&buf}
-		encodings                         = r.ContentEncoding()
-		writerConstructors                = defaultEncodingWriters
+		buf      bytes.Buffer
+		writer   io.WriteCloser
+		closeAll func() error
+	)
+	if writer, closeAll, err = r.encoders(&closeableBuffer{&buf}); err != nil {
+		return
+	}
+	if _, err = writer.Write(b); err != nil {
+		return
+	}
+	if err = closeAll(); err != nil {
+		return
+	}
+	bb = buf.Bytes()
+	return
+}
+
+// encoders wraps the provided writer with the encoding writer for each of
+// the content encodings of the representation, such that the content
+// encodings are applied in the order they are listed. The function returned
+// closes each of the encoding writers, from the outermost to the innermost.
+func (r *Base) encoders(w io.WriteCloser) (io.WriteCloser, func() error, error) {
+	var (
+		writers            = []io.WriteCloser{w}
+		encodings          = r.ContentEncoding()
+		writerConstructors = defaultEncodingWriters
 	)
 
 	if len(r.encodingWriters) > 0 {
 		writerConstructors = r.encodingWriters
 	}
 
-	for _, e := range encodings {
-		if _, ok := writerConstructors[strings.ToLower(e)]; !ok {
-			err = ErrUnsupportedContentEncoding
-			return
+	closeAll := func() error {
+		for idx := len(writers) - 1; idx > 0; idx-- {
+			if err := writers[idx].Close(); err != nil {
+				return err
+			}
 		}
-		if writer, err = writerConstructors[strings.ToLower(e)](writer); err != nil {
-			return
+		return nil
+	}
+	if len(encodings) < 1 || strings.ToLower(encodings[0]) == "identity" {
+		return w, closeAll, nil
+	}
+	for idx := len(encodings) - 1; idx >= 0; idx-- {
+		constructor, ok := writerConstructors[strings.ToLower(encodings[idx])]
+		if !ok {
+			return nil, nil, ErrUnsupportedContentEncoding
 		}
+		writer, err := constructor(writers[len(writers)-1])
+		if err != nil {
+			return nil, nil, err
+		}
+		writers = append(writers, writer)
 	}
-	if _, err = writer.Write(b); err != nil {
-		return
+	return writers[len(writers)-1], closeAll, nil
+}
+
+// Stream writes the serialized form of the representation to the provided
+// writer, encoding it as it is written rather than buffering it. When the
+// content type of the representation has no stream marshaller, the
+// serialized form is buffered instead.
+func (r Base) Stream(w io.Writer, out interface{}) (int64, error) {
+	marshallers := defaultStreamMarshallers
+	if len(r.streamMarshallers) > 0 {
+		marshallers = r.streamMarshallers
+	} else if len(r.marshallers) > 0 {
+		marshallers = nil
 	}
-	if err = writer.Close(); err != nil {
-		return
+
+	ct := strings.ToLower(strings.Split(r.ContentType(), ";")[0])
+	marshal, ok := marshallers[ct]
+	if !ok {
+		b, err := r.Bytes(out)
+		if err != nil {
+			return 0, err
+		}
+		n, err := w.Write(b)
+		return int64(n), err
 	}
-	bb = buf.Bytes()
-	return
+
+	cw := &countingWriter{w: w}
+	writer, closeAll, err := r.encoders(cw)
+	if err != nil {
+		return 0, err
+	}
+	if err = marshal(writer, out); err != nil {
+		return cw.n, err
+	}
+	err = closeAll()
+	return cw.n, err
 }
 
 // FromBytes constructs the representation from its serialized form.
@@ -236,8 +350,9 @@ func (r *Base) decode(b []byte) (bb []byte, err error) {
 	var (
 		buf                              = bytes.NewBuffer(b)
 		reader             io.ReadCloser = &closeableBuffer{buf}
-		encodings                        = r.ContentEncoding()
-		readerConstructors               = defaultEncodingReaders
+		readers            []io.ReadCloser
+		encodings          = r.ContentEncoding()
+		readerConstructors = defaultEncodingReaders
 	)
 
 	if len(r.encodingReaders) > 0 {
@@ -253,16 +368,38 @@ func (r *Base) decode(b []byte) (bb []byte, err error) {
 		if reader, err = readerConstructors[strings.ToLower(e)](reader); err != nil {
 			return
 		}
-		defer func() {
-			err = reader.Close()
-		}()
+		readers = append(readers, reader)
 	}
 	if bb, err = io.ReadAll(reader); err != nil {
 		return
 	}
+	for idx := len(readers) - 1; idx >= 0; idx-- {
+		if err = readers[idx].Close(); err != nil {
+			return
+		}
+	}
 	return
 }
 
+// countingWriter represents a closeable writer that counts the bytes
+// written to the underlying writer.
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+// Close closes the writer, leaving the underlying writer open.
+func (cw *countingWriter) Close() error {
+	return nil
+}
+
+// Write writes the provided bytes to the underlying writer.
+func (cw *countingWriter) Write(b []byte) (int, error) {
+	n, err := cw.w.Write(b)
+	cw.n += int64(n)
+	return n, err
+}
+
 // closeableBuffer represents a closeable buffer.
 type closeableBuffer struct {
 	buf *bytes.Buffer
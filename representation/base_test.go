@@ -17,6 +17,8 @@ package representation_test
 
 import (
 	"bytes"
+	"compress/flate"
+	"compress/gzip"
 	"io"
 	"net/url"
 	"testing"
@@ -507,6 +509,141 @@ func (s BaseTestSuite) TestBaseRepresentation_SetEncodingWriters() {
 	})
 }
 
+func (s BaseTestSuite) TestBaseRepresentation_Stream() {
+	tests := []struct {
+		name      string
+		mediaType string
+		encoding  []string
+		err       error
+	}{
+		{"IdentityJSON", "application/json", []string{"identity"}, nil},
+		{"GzippedJSON", "application/json", []string{"gzip"}, nil},
+		{"DeflatedYAML", "application/yaml", []string{"deflate"}, nil},
+		{"CompressedXML", "application/xml", []string{"compress"}, nil},
+		{"GzippedDeflatedJSON", "application/json", []string{"gzip", "deflate"}, nil},
+		{"UnsupportedMediaType", "application/beeboop", nil, representation.ErrUnsupportedContentType},
+		{"UnsupportedContentEncoding", "application/json", []string{"beeboop"}, representation.ErrUnsupportedContentEncoding},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			// arrange.
+			in := test.Representation{A: "TEST", B: 28}
+			in.SetContentType(tt.mediaType)
+			in.SetContentEncoding(tt.encoding)
+			var buf bytes.Buffer
+
+			// action.
+			n, err := in.Base.Stream(&buf, in)
+
+			// assert.
+			if tt.err != nil {
+				s.Require().ErrorIs(err, tt.err)
+				return
+			}
+			s.Require().NoError(err)
+			s.Equal(int64(buf.Len()), n)
+			out := test.Representation{}
+			out.SetContentType(tt.mediaType)
+			out.SetContentEncoding(tt.encoding)
+			s.Require().NoError(out.Base.FromBytes(buf.Bytes(), &out))
+			s.Equal(in.A, out.A)
+			s.Equal(in.B, out.B)
+		})
+	}
+}
+
+func (s BaseTestSuite) TestBaseRepresentation_Stream_EncodingOrder() {
+	// arrange.
+	rep := test.Representation{A: "TEST", B: 28}
+	rep.SetContentType("application/json")
+	rep.SetContentEncoding([]string{"gzip", "deflate"})
+	var buf bytes.Buffer
+
+	// action.
+	_, err := rep.Base.Stream(&buf, rep)
+
+	// assert.
+	s.Require().NoError(err)
+	deflated := flate.NewReader(&buf)
+	gzipped, err := gzip.NewReader(deflated)
+	s.Require().NoError(err)
+	b, err := io.ReadAll(gzipped)
+	s.Require().NoError(err)
+	s.JSONEq(`{"A":"TEST","B":28}`, string(b))
+}
+
+func (s BaseTestSuite) TestBaseRepresentation_Stream_Marshallers() {
+	// arrange.
+	testContentType := "text/test"
+	rep := test.Representation{A: "TEST", B: 28}
+	rep.SetContentType(testContentType)
+	rep.SetMarshallers(map[string]representation.Marshaller{
+		testContentType: func(in interface{}) ([]byte, error) {
+			return []byte("test"), nil
+		},
+	})
+	var buf bytes.Buffer
+
+	// action.
+	n, err := rep.Base.Stream(&buf, rep)
+
+	// assert.
+	s.Require().NoError(err)
+	s.Equal(int64(4), n)
+	s.Equal("test", buf.String())
+}
+
+func (s BaseTestSuite) TestBaseRepresentation_SetStreamMarshallers() {
+	// arrange.
+	testContentType := "text/test"
+	rep := test.Representation{A: "TEST", B: 28}
+	rep.SetContentType(testContentType)
+
+	// action.
+	rep.SetStreamMarshallers(map[string]representation.StreamMarshaller{
+		testContentType: func(w io.Writer, in interface{}) error {
+			_, err := w.Write([]byte("streamed"))
+			return err
+		},
+	})
+
+	// assert.
+	var buf bytes.Buffer
+	_, err := rep.Base.Stream(&buf, rep)
+	s.Require().NoError(err)
+	s.Equal("streamed", buf.String())
+}
+
+func (s BaseTestSuite) TestBaseRepresentation_SetContentDescription() {
+	// arrange.
+	rep := test.Representation{A: "TEST", B: 28}
+
+	// action.
+	rep.SetContentDescription("A test", "en")
+
+	// assert.
+	text, language := representation.ContentDescription(rep)
+	s.Equal("A test", text)
+	s.Equal("en", language)
+}
+
+func (s BaseTestSuite) TestBaseRepresentation_SetExtensionAttribute() {
+	// arrange.
+	rep := test.Representation{A: "TEST", B: 28}
+	rep.SetExtensionAttribute("x-a", "1")
+	other := rep
+
+	// action.
+	other.SetExtensionAttribute("x-b", "2")
+
+	// assert.
+	s.Equal(map[string]string{"x-a": "1"}, representation.ExtensionAttributes(rep))
+	s.Equal(map[string]string{"x-a": "1", "x-b": "2"}, representation.ExtensionAttributes(other))
+	ranked := representation.RankedRepresentation{Representation: other}
+	s.Equal(map[string]string{"x-a": "1", "x-b": "2"}, representation.ExtensionAttributes(ranked))
+}
+
 // closeableBuffer represents a closeable buffer.
 type closeableBuffer struct {
 	buf *bytes.Buffer
@@ -0,0 +1,83 @@
+/* Copyright 2020 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package representation
+
+// Dimension represents a dimension of content negotiation, identified by the
+// request header that user agents utilize to communicate their preferences
+// for that dimension.
+type Dimension string
+
+// Dimensions of content negotiation.
+const (
+	// DimensionMediaType represents the media type dimension, communicated
+	// with the Accept header.
+	DimensionMediaType Dimension = "Accept"
+
+	// DimensionLanguage represents the language dimension, communicated with
+	// the Accept-Language header.
+	DimensionLanguage Dimension = "Accept-Language"
+
+	// DimensionCharset represents the charset dimension, communicated with
+	// the Accept-Charset header.
+	DimensionCharset Dimension = "Accept-Charset"
+
+	// DimensionEncoding represents the content coding dimension, communicated
+	// with the Accept-Encoding header.
+	DimensionEncoding Dimension = "Accept-Encoding"
+
+	// DimensionFeatures represents the feature dimension, communicated with
+	// the Accept-Features header.
+	DimensionFeatures Dimension = "Accept-Features"
+
+	// DimensionNegotiate represents the transparent negotiation capabilities
+	// of the user agent, communicated with the Negotiate header.
+	DimensionNegotiate Dimension = "Negotiate"
+)
+
+// DefaultDimensions are the dimensions assumed to be consulted by choosers
+// that are unable to report the dimensions they consult.
+var DefaultDimensions = []Dimension{
+	DimensionMediaType,
+	DimensionLanguage,
+	DimensionCharset,
+	DimensionEncoding,
+}
+
+// String provides the textual representation of the dimension, which is the
+// name of the request header that communicates it.
+func (d Dimension) String() string {
+	return string(d)
+}
+
+// DimensionReporter is implemented by choosers that are capable of reporting
+// the dimensions they consult when choosing a representation.
+//
+// Negotiators utilize the reported dimensions to produce an accurate Vary
+// header, so custom choosers should implement this interface whenever they
+// consult fewer (or more) dimensions than DefaultDimensions.
+type DimensionReporter interface {
+	Dimensions() []Dimension
+}
+
+// DimensionsOf provides the dimensions consulted by the provided chooser.
+// Choosers that do not implement DimensionReporter are assumed to consult
+// the DefaultDimensions.
+func DimensionsOf(c Chooser) []Dimension {
+	if r, ok := c.(DimensionReporter); ok {
+		return r.Dimensions()
+	}
+	return DefaultDimensions
+}
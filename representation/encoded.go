@@ -0,0 +1,158 @@
+/* Copyright 2020 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package representation
+
+import (
+	"bytes"
+	"io"
+	"sort"
+	"strings"
+)
+
+// ContentCodings provides the content codings that can be applied to
+// representations, sorted alphabetically.
+func ContentCodings() []string {
+	var codings []string
+	for coding := range defaultEncodingWriters {
+		codings = append(codings, coding)
+	}
+	sort.Strings(codings)
+	return codings
+}
+
+// Encoded represents a representation with a content coding that is applied
+// dynamically as the representation is serialized.
+//
+// The description, extension attributes, and entity tag of the unencoded
+// representation are forwarded, with strong entity tags made distinct for
+// the content coding. Content lengths are not forwarded, as the content
+// coding changes them.
+type Encoded struct {
+	Representation
+
+	coding string
+}
+
+// NewEncoded constructs a representation that applies the provided content
+// coding to the provided representation as it is serialized.
+func NewEncoded(rep Representation, coding string) (Encoded, error) {
+	if _, ok := defaultEncodingWriters[strings.ToLower(coding)]; !ok {
+		return Encoded{}, ErrUnsupportedContentEncoding
+	}
+	return Encoded{Representation: rep, coding: coding}, nil
+}
+
+// Unencoded retrieves the representation prior to applying the content
+// coding.
+func (e Encoded) Unencoded() Representation {
+	return e.Representation
+}
+
+// ContentEncoding retrieves the content encoding of the representation,
+// which includes the content coding applied dynamically.
+func (e Encoded) ContentEncoding() []string {
+	var encodings []string
+	for _, ce := range e.Representation.ContentEncoding() {
+		if !strings.EqualFold(ce, "identity") {
+			encodings = append(encodings, ce)
+		}
+	}
+	return append(encodings, e.coding)
+}
+
+// ContentDescription provides the textual description of the unencoded
+// representation, along with the language tag of the language it is written
+// in, if any.
+func (e Encoded) ContentDescription() (string, string) {
+	return ContentDescription(e.Representation)
+}
+
+// ExtensionAttributes provides the extension attributes of the unencoded
+// representation, keyed on their names.
+func (e Encoded) ExtensionAttributes() map[string]string {
+	return ExtensionAttributes(e.Representation)
+}
+
+// EntityTag provides the entity tag of the unencoded representation, along
+// with an indication of whether it is known. Strong entity tags have the
+// content coding appended to their opaque value, as the serialized form
+// differs from that of the unencoded representation, while weak entity tags
+// are provided as is.
+func (e Encoded) EntityTag() (string, bool) {
+	t, ok := e.Representation.(EntityTagger)
+	if !ok {
+		return "", false
+	}
+	tag, known := t.EntityTag()
+	if !known || strings.HasPrefix(tag, "W/") {
+		return tag, known
+	}
+	if len(tag) < 2 || !strings.HasPrefix(tag, `"`) || !strings.HasSuffix(tag, `"`) {
+		return "", false
+	}
+	return tag[:len(tag)-1] + "-" + strings.ToLower(e.coding) + `"`, true
+}
+
+// Bytes retrieves the serialized form of the representation with the
+// content coding applied.
+func (e Encoded) Bytes() ([]byte, error) {
+	var buf bytes.Buffer
+	if _, err := e.WriteTo(&buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// WriteTo writes the serialized form of the representation to the provided
+// writer, applying the content coding as it is written.
+func (e Encoded) WriteTo(w io.Writer) (int64, error) {
+	cw := &countingWriter{w: w}
+	writer, err := defaultEncodingWriters[strings.ToLower(e.coding)](cw)
+	if err != nil {
+		return 0, err
+	}
+	if wt, ok := e.Representation.(io.WriterTo); ok {
+		if _, err = wt.WriteTo(writer); err != nil {
+			return cw.n, err
+		}
+	} else {
+		var b []byte
+		if b, err = e.Representation.Bytes(); err != nil {
+			return cw.n, err
+		}
+		if _, err = writer.Write(b); err != nil {
+			return cw.n, err
+		}
+	}
+	err = writer.Close()
+	return cw.n, err
+}
+
+// FromBytes constructs the representation from its serialized form, which
+// has the content coding applied.
+func (e Encoded) FromBytes(b []byte) (err error) {
+	reader, err := defaultEncodingReaders[strings.ToLower(e.coding)](&closeableBuffer{bytes.NewBuffer(b)})
+	if err != nil {
+		return err
+	}
+	if b, err = io.ReadAll(reader); err != nil {
+		return err
+	}
+	if err = reader.Close(); err != nil {
+		return err
+	}
+	return e.Representation.FromBytes(b)
+}
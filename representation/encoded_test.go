@@ -0,0 +1,176 @@
+/* Copyright 2020 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package representation_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"testing"
+
+	"github.com/freerware/negotiator/representation"
+	"github.com/stretchr/testify/suite"
+)
+
+type EncodedTestSuite struct {
+	suite.Suite
+}
+
+func TestEncodedTestSuite(t *testing.T) {
+	suite.Run(t, new(EncodedTestSuite))
+}
+
+func (s EncodedTestSuite) TestContentCodings() {
+	// action.
+	codings := representation.ContentCodings()
+
+	// assert.
+	s.Equal([]string{"compress", "deflate", "gzip", "x-compress", "x-gzip"}, codings)
+}
+
+func (s EncodedTestSuite) TestNewEncoded_UnsupportedCoding() {
+	// arrange.
+	reps, err := representation.Of(thing{ID: 1, Name: "one"}, representation.MediaTypes("application/json"))
+	s.Require().NoError(err)
+
+	// action.
+	_, err = representation.NewEncoded(reps[0], "br")
+
+	// assert.
+	s.ErrorIs(err, representation.ErrUnsupportedContentEncoding)
+}
+
+func (s EncodedTestSuite) TestEncoded_ContentEncoding() {
+	// arrange.
+	reps, err := representation.Of(thing{ID: 1, Name: "one"}, representation.MediaTypes("application/json"))
+	s.Require().NoError(err)
+
+	// action.
+	sut, err := representation.NewEncoded(reps[0], "gzip")
+
+	// assert.
+	s.Require().NoError(err)
+	s.Equal([]string{"gzip"}, sut.ContentEncoding())
+	s.Equal(reps[0], sut.Unencoded())
+	s.Equal("application/json", sut.ContentType())
+}
+
+func (s EncodedTestSuite) TestEncoded_WriteTo() {
+	// arrange.
+	reps, err := representation.Of(thing{ID: 1, Name: "one"}, representation.MediaTypes("application/json"))
+	s.Require().NoError(err)
+	sut, err := representation.NewEncoded(reps[0], "gzip")
+	s.Require().NoError(err)
+	var buf bytes.Buffer
+
+	// action.
+	n, err := sut.WriteTo(&buf)
+
+	// assert.
+	s.Require().NoError(err)
+	s.Equal(int64(buf.Len()), n)
+	reader, err := gzip.NewReader(&buf)
+	s.Require().NoError(err)
+	b, err := io.ReadAll(reader)
+	s.Require().NoError(err)
+	s.Equal("{\"id\":1,\"name\":\"one\"}\n", string(b))
+}
+
+func (s EncodedTestSuite) TestEncoded_RoundTrip() {
+	for _, coding := range representation.ContentCodings() {
+		s.Run(coding, func() {
+			// arrange.
+			reps, err := representation.Of(thing{ID: 1, Name: "one"}, representation.MediaTypes("application/json"))
+			s.Require().NoError(err)
+			sut, err := representation.NewEncoded(reps[0], coding)
+			s.Require().NoError(err)
+			b, err := sut.Bytes()
+			s.Require().NoError(err)
+			target, err := representation.Of(thing{}, representation.MediaTypes("application/json"))
+			s.Require().NoError(err)
+			out, err := representation.NewEncoded(target[0], coding)
+			s.Require().NoError(err)
+
+			// action.
+			err = out.FromBytes(b)
+
+			// assert.
+			s.Require().NoError(err)
+			value := target[0].(*representation.Value[thing]).Value()
+			s.Equal(thing{ID: 1, Name: "one"}, value)
+		})
+	}
+}
+
+func (s EncodedTestSuite) TestEncoded_EntityTag() {
+	// arrange.
+	reps, err := representation.Of(thing{ID: 1, Name: "one"}, representation.MediaTypes("application/json"))
+	s.Require().NoError(err)
+	tests := []struct {
+		name  string
+		in    representation.Representation
+		tag   string
+		known bool
+	}{
+		{"Strong", tagged{Representation: reps[0], tag: `"xyzzy"`}, `"xyzzy-gzip"`, true},
+		{"Weak", tagged{Representation: reps[0], tag: `W/"xyzzy"`}, `W/"xyzzy"`, true},
+		{"Unknown", tagged{Representation: reps[0]}, "", false},
+		{"Untagged", reps[0], "", false},
+	}
+	for _, test := range tests {
+		s.Run(test.name, func() {
+			// arrange.
+			sut, err := representation.NewEncoded(test.in, "gzip")
+			s.Require().NoError(err)
+
+			// action.
+			tag, known := sut.EntityTag()
+
+			// assert.
+			s.Equal(test.known, known)
+			s.Equal(test.tag, tag)
+		})
+	}
+}
+
+func (s EncodedTestSuite) TestEncoded_Attributes() {
+	// arrange.
+	rep := representation.NewFile(nil, "thing.html")
+	rep.SetContentDescription("thing", "en")
+	rep.SetExtensionAttribute("x-thing", "1")
+	sut, err := representation.NewEncoded(rep, "gzip")
+	s.Require().NoError(err)
+
+	// action.
+	text, language := sut.ContentDescription()
+	attributes := sut.ExtensionAttributes()
+
+	// assert.
+	s.Equal("thing", text)
+	s.Equal("en", language)
+	s.Equal(map[string]string{"x-thing": "1"}, attributes)
+}
+
+// tagged represents a representation with an entity tag.
+type tagged struct {
+	representation.Representation
+
+	tag string
+}
+
+func (t tagged) EntityTag() (string, bool) {
+	return t.tag, len(t.tag) > 0
+}
@@ -0,0 +1,27 @@
+/* Copyright 2020 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package representation
+
+// EntityTagger is implemented by representations that are capable of
+// providing their entity tag without being serialized, such as those backed
+// by a store that tracks revisions.
+type EntityTagger interface {
+	// EntityTag provides the entity tag of the representation, such as
+	// `"xyzzy"` or `W/"xyzzy"`, along with an indication of whether the
+	// entity tag is known. Strong entity tags must change whenever the
+	// serialized form of the representation changes.
+	EntityTag() (string, bool)
+}
@@ -0,0 +1,94 @@
+/* Copyright 2020 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package representation
+
+import (
+	"errors"
+	"io"
+	"io/fs"
+)
+
+// ErrReadOnly is an error that indicates that the representation cannot be
+// constructed from its serialized form.
+var ErrReadOnly = errors.New("representation is read only")
+
+// File represents a representation whose serialized form is the content of a
+// file, such as the variants described within a type map. The content is
+// served as is, meaning it is neither marshalled nor encoded, and therefore
+// must already be in the content type and content encoding of the
+// representation.
+type File struct {
+	Base
+
+	fsys   fs.FS
+	name   string
+	length int
+}
+
+// NewFile constructs a representation whose serialized form is the content
+// of the file with the provided name within the provided file system.
+func NewFile(fsys fs.FS, name string) *File {
+	return &File{fsys: fsys, name: name, length: -1}
+}
+
+// Name retrieves the name of the file within the file system.
+func (f *File) Name() string { return f.name }
+
+// ContentLengthHint provides the content length of the representation when
+// it has been specified, falling back to the size of the file, along with an
+// indication of whether it is known.
+func (f *File) ContentLengthHint() (int, bool) {
+	if f.length >= 0 {
+		return f.length, true
+	}
+	return f.ContentLength()
+}
+
+// SetContentLength modifies the content length hint of the representation,
+// such as the length declared within a type map, which is only utilized to
+// rank representations.
+func (f *File) SetContentLength(length int) { f.length = length }
+
+// ContentLength provides the size of the file, along with an indication of
+// whether it is known, regardless of the content length hint.
+func (f *File) ContentLength() (int, bool) {
+	info, err := fs.Stat(f.fsys, f.name)
+	if err != nil || !info.Mode().IsRegular() {
+		return 0, false
+	}
+	return int(info.Size()), true
+}
+
+// Bytes retrieves the content of the file.
+func (f *File) Bytes() ([]byte, error) {
+	return fs.ReadFile(f.fsys, f.name)
+}
+
+// WriteTo writes the content of the file to the provided writer without
+// buffering it.
+func (f *File) WriteTo(w io.Writer) (int64, error) {
+	file, err := f.fsys.Open(f.name)
+	if err != nil {
+		return 0, err
+	}
+	defer file.Close()
+	return io.Copy(w, file)
+}
+
+// FromBytes fails, as the content of the file is never modified.
+func (f *File) FromBytes([]byte) error {
+	return ErrReadOnly
+}
@@ -0,0 +1,129 @@
+/* Copyright 2020 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package representation
+
+import (
+	"io"
+	"sync"
+	"sync/atomic"
+)
+
+// Lazy represents a representation whose metadata is known up front, while
+// the value being represented is only produced once the representation is
+// serialized. Both the value and the serialized form are memoized, so the
+// callback is invoked at most once.
+type Lazy[T any] struct {
+	Base
+
+	length     int
+	value      func() (T, error)
+	bytes      func() ([]byte, error)
+	marshalled atomic.Bool
+}
+
+// NewLazy constructs a lazy representation whose value is produced by the
+// provided callback.
+func NewLazy[T any](fn func() (T, error)) *Lazy[T] {
+	return newLazy(sync.OnceValues(fn))
+}
+
+// newLazy constructs a lazy representation whose value is produced by the
+// provided memoized callback.
+func newLazy[T any](value func() (T, error)) *Lazy[T] {
+	l := &Lazy[T]{length: -1, value: value}
+	l.bytes = sync.OnceValues(l.marshal)
+	return l
+}
+
+// marshal serializes the value produced by the callback.
+func (l *Lazy[T]) marshal() ([]byte, error) {
+	v, err := l.value()
+	if err != nil {
+		return nil, err
+	}
+	b, err := l.Base.Bytes(v)
+	if err == nil {
+		l.marshalled.Store(true)
+	}
+	return b, err
+}
+
+// ContentLengthHint provides the content length of the representation when
+// it has been specified or the serialized form has been memoized, along with
+// an indication of whether it is known.
+func (l *Lazy[T]) ContentLengthHint() (int, bool) {
+	if l.length < 0 && l.marshalled.Load() {
+		b, _ := l.bytes()
+		return len(b), true
+	}
+	return l.length, l.length >= 0
+}
+
+// SetContentLength modifies the content length hint of the representation.
+func (l *Lazy[T]) SetContentLength(length int) { l.length = length }
+
+// ContentLength provides the exact content length of the representation,
+// which is only known once the serialized form has been memoized.
+func (l *Lazy[T]) ContentLength() (int, bool) {
+	if !l.marshalled.Load() {
+		return 0, false
+	}
+	b, _ := l.bytes()
+	return len(b), true
+}
+
+// Value retrieves the value being represented, invoking the callback when
+// it has not yet been invoked.
+func (l *Lazy[T]) Value() (T, error) {
+	return l.value()
+}
+
+// Bytes retrieves the serialized form of the lazy representation.
+func (l *Lazy[T]) Bytes() ([]byte, error) {
+	return l.bytes()
+}
+
+// WriteTo writes the serialized form of the lazy representation to the
+// provided writer without buffering it, unless the serialized form has
+// already been memoized.
+func (l *Lazy[T]) WriteTo(w io.Writer) (int64, error) {
+	if l.marshalled.Load() {
+		b, err := l.bytes()
+		if err != nil {
+			return 0, err
+		}
+		n, err := w.Write(b)
+		return int64(n), err
+	}
+	v, err := l.value()
+	if err != nil {
+		return 0, err
+	}
+	return l.Base.Stream(w, v)
+}
+
+// FromBytes constructs the lazy representation from its serialized form,
+// replacing the value produced by the callback.
+func (l *Lazy[T]) FromBytes(b []byte) error {
+	var v T
+	if err := l.Base.FromBytes(b, &v); err != nil {
+		return err
+	}
+	l.value = func() (T, error) { return v, nil }
+	l.marshalled.Store(false)
+	l.bytes = sync.OnceValues(l.marshal)
+	return nil
+}
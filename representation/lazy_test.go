@@ -0,0 +1,288 @@
+/* Copyright 2020 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package representation_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/freerware/negotiator/representation"
+	"github.com/stretchr/testify/suite"
+)
+
+type LazyTestSuite struct {
+	suite.Suite
+}
+
+func TestLazyTestSuite(t *testing.T) {
+	suite.Run(t, new(LazyTestSuite))
+}
+
+func (s LazyTestSuite) TestLazy_Bytes() {
+	// arrange.
+	invocations := 0
+	sut := representation.NewLazy(func() (thing, error) {
+		invocations++
+		return thing{ID: 1, Name: "one"}, nil
+	})
+	sut.SetContentType("application/json")
+
+	// action.
+	first, err := sut.Bytes()
+	s.Require().NoError(err)
+	second, err := sut.Bytes()
+	s.Require().NoError(err)
+
+	// assert.
+	s.Equal(1, invocations)
+	s.Equal(`{"id":1,"name":"one"}`, string(first))
+	s.Equal(first, second)
+}
+
+func (s LazyTestSuite) TestLazy_Bytes_Error() {
+	// arrange.
+	errExpected := errors.New("whoa")
+	sut := representation.NewLazy(func() (thing, error) {
+		return thing{}, errExpected
+	})
+	sut.SetContentType("application/json")
+
+	// action.
+	b, err := sut.Bytes()
+
+	// assert.
+	s.Require().ErrorIs(err, errExpected)
+	s.Nil(b)
+}
+
+func (s LazyTestSuite) TestLazy_FromBytes() {
+	// arrange.
+	sut := representation.NewLazy(func() (thing, error) {
+		return thing{ID: 1, Name: "one"}, nil
+	})
+	sut.SetContentType("application/json")
+	sut.SetContentLanguage("en-US")
+
+	// action.
+	err := sut.FromBytes([]byte(`{"id":2,"name":"two"}`))
+
+	// assert.
+	s.Require().NoError(err)
+	v, err := sut.Value()
+	s.Require().NoError(err)
+	s.Equal(thing{ID: 2, Name: "two"}, v)
+	s.Equal("en-US", sut.ContentLanguage())
+	b, err := sut.Bytes()
+	s.Require().NoError(err)
+	s.Equal(`{"id":2,"name":"two"}`, string(b))
+}
+
+func (s LazyTestSuite) TestLazy_ContentLengthHint() {
+	// arrange.
+	sut := representation.NewLazy(func() (thing, error) {
+		return thing{}, nil
+	})
+
+	// action + assert.
+	_, known := sut.ContentLengthHint()
+	s.False(known)
+	sut.SetContentLength(42)
+	length, known := sut.ContentLengthHint()
+	s.True(known)
+	s.Equal(42, length)
+}
+
+func (s LazyTestSuite) TestLazy_WriteTo() {
+	// arrange.
+	invocations := 0
+	sut := representation.NewLazy(func() (thing, error) {
+		invocations++
+		return thing{ID: 1, Name: "one"}, nil
+	})
+	sut.SetContentType("application/yaml")
+	var buf bytes.Buffer
+
+	// action.
+	n, err := sut.WriteTo(&buf)
+
+	// assert.
+	s.Require().NoError(err)
+	s.Equal(1, invocations)
+	s.Equal(int64(buf.Len()), n)
+	s.Equal("id: 1\nname: one\n", buf.String())
+}
+
+func (s LazyTestSuite) TestLazy_WriteTo_Memoized() {
+	// arrange.
+	marshals, streams := 0, 0
+	sut := representation.NewLazy(func() (thing, error) {
+		return thing{ID: 1, Name: "one"}, nil
+	})
+	sut.SetContentType("application/json")
+	sut.SetMarshallers(map[string]representation.Marshaller{
+		"application/json": func(v interface{}) ([]byte, error) {
+			marshals++
+			return json.Marshal(v)
+		},
+	})
+	sut.SetStreamMarshallers(map[string]representation.StreamMarshaller{
+		"application/json": func(w io.Writer, v interface{}) error {
+			streams++
+			return json.NewEncoder(w).Encode(v)
+		},
+	})
+	b, err := sut.Bytes()
+	s.Require().NoError(err)
+	var buf bytes.Buffer
+
+	// action.
+	n, err := sut.WriteTo(&buf)
+
+	// assert.
+	s.Require().NoError(err)
+	s.Equal(1, marshals)
+	s.Zero(streams)
+	s.Equal(int64(len(b)), n)
+	s.Equal(b, buf.Bytes())
+}
+
+func (s LazyTestSuite) TestLazyOf() {
+	// arrange.
+	invocations := 0
+	reps, err := representation.LazyOf(func() (thing, error) {
+		invocations++
+		return thing{ID: 1, Name: "one"}, nil
+	}, representation.MediaTypes("application/json", "application/yaml"))
+	s.Require().NoError(err)
+	s.Require().Len(reps, 2)
+	s.Zero(invocations)
+
+	// action.
+	json, err := reps[0].Bytes()
+	s.Require().NoError(err)
+	yaml, err := reps[1].Bytes()
+	s.Require().NoError(err)
+
+	// assert.
+	s.Equal(1, invocations)
+	s.Equal(`{"id":1,"name":"one"}`, string(json))
+	s.Equal("id: 1\nname: one\n", string(yaml))
+}
+
+func (s LazyTestSuite) TestLazy_ContentLength() {
+	// arrange.
+	sut := representation.NewLazy(func() (thing, error) {
+		return thing{ID: 1, Name: "one"}, nil
+	})
+	sut.SetContentType("application/json")
+	sut.SetContentLength(5)
+
+	// action + assert.
+	_, known := sut.ContentLength()
+	s.False(known)
+	b, err := sut.Bytes()
+	s.Require().NoError(err)
+	length, known := sut.ContentLength()
+	s.True(known)
+	s.Equal(len(b), length)
+}
+
+func (s LazyTestSuite) TestContentLength() {
+	// arrange.
+	invocations := 0
+	hinted := representation.NewLazy(func() (thing, error) {
+		invocations++
+		return thing{}, nil
+	})
+	hinted.SetContentType("application/json")
+	hinted.SetContentLength(42)
+	unhinted := representation.NewLazy(func() (thing, error) {
+		return thing{ID: 1, Name: "one"}, nil
+	})
+	unhinted.SetContentType("application/json")
+	list := representation.List{}
+	list.SetContentType("application/json")
+	listBytes, _ := list.Bytes()
+
+	tests := []struct {
+		name string
+		in   representation.Representation
+		out  int
+	}{
+		{"Hint", hinted, 42},
+		{"RankedHint", representation.RankedRepresentation{Representation: hinted}, 42},
+		{"Serialized", &list, len(listBytes)},
+		{"Streamed", unhinted, len(`{"id":1,"name":"one"}` + "\n")},
+	}
+
+	for _, test := range tests {
+		s.Run(test.name, func() {
+			// action.
+			length, err := representation.ContentLength(test.in)
+
+			// assert.
+			s.Require().NoError(err)
+			s.Equal(test.out, length)
+		})
+	}
+	s.Zero(invocations)
+}
+
+func (s LazyTestSuite) TestKnownContentLength() {
+	// arrange.
+	invocations := 0
+	lazy := func() *representation.Lazy[thing] {
+		l := representation.NewLazy(func() (thing, error) {
+			invocations++
+			return thing{ID: 1, Name: "one"}, nil
+		})
+		l.SetContentType("application/json")
+		return l
+	}
+	hinted, unhinted := lazy(), lazy()
+	hinted.SetContentLength(42)
+	list := representation.List{}
+	list.SetContentType("application/json")
+	listBytes, _ := list.Bytes()
+
+	tests := []struct {
+		name   string
+		in     representation.Representation
+		length int
+		known  bool
+	}{
+		{"Hint", hinted, 42, true},
+		{"RankedHint", representation.RankedRepresentation{Representation: hinted}, 42, true},
+		{"NoHint", unhinted, 0, false},
+		{"Serialized", &list, len(listBytes), true},
+	}
+
+	for _, test := range tests {
+		s.Run(test.name, func() {
+			// action.
+			length, known, err := representation.KnownContentLength(test.in)
+
+			// assert.
+			s.Require().NoError(err)
+			s.Equal(test.known, known)
+			s.Equal(test.length, length)
+		})
+	}
+	s.Zero(invocations)
+}
@@ -0,0 +1,84 @@
+/* Copyright 2020 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package representation
+
+import "io"
+
+// ContentLengthHinter is implemented by representations that are capable of
+// providing their content length without being serialized.
+type ContentLengthHinter interface {
+	// ContentLengthHint provides the content length of the representation,
+	// along with an indication of whether the content length is known.
+	ContentLengthHint() (int, bool)
+}
+
+// ContentLengthReporter is implemented by representations that are capable of
+// reporting their exact content length without being serialized. Unlike
+// content length hints, which are estimates that are only utilized when
+// ranking representations, the reported content length is guaranteed to match
+// the serialized form.
+type ContentLengthReporter interface {
+	// ContentLength provides the exact content length of the representation,
+	// along with an indication of whether it is known.
+	ContentLength() (int, bool)
+}
+
+// ContentLength determines the content length of the provided
+// representation, utilizing the content length hint when available. Otherwise,
+// representations that can be streamed are written to a writer that discards
+// the serialized form, and all others are serialized.
+func ContentLength(rep Representation) (int, error) {
+	if r, ok := rep.(RankedRepresentation); ok {
+		rep = r.Representation
+	}
+	if h, ok := rep.(ContentLengthHinter); ok {
+		if length, known := h.ContentLengthHint(); known {
+			return length, nil
+		}
+	}
+	if wt, ok := rep.(io.WriterTo); ok {
+		n, err := wt.WriteTo(io.Discard)
+		return int(n), err
+	}
+	b, err := rep.Bytes()
+	if err != nil {
+		return 0, err
+	}
+	return len(b), nil
+}
+
+// KnownContentLength determines the content length of the provided
+// representation, along with an indication of whether it is known, without
+// serializing representations that are capable of providing a content length
+// hint. The content length of such representations is unknown when they
+// provide no hint, while all other representations are measured as with
+// ContentLength.
+func KnownContentLength(rep Representation) (int, bool, error) {
+	if r, ok := rep.(RankedRepresentation); ok {
+		rep = r.Representation
+	}
+	if h, ok := rep.(ContentLengthHinter); ok {
+		if length, known := h.ContentLengthHint(); known {
+			return length, true, nil
+		}
+		return 0, false, nil
+	}
+	length, err := ContentLength(rep)
+	if err != nil {
+		return 0, false, err
+	}
+	return length, true, nil
+}
@@ -38,16 +38,21 @@ type List struct {
 // SetRepresentations modifies the representing list within the list representation.
 func (l *List) SetRepresentations(reps ...Representation) {
 	for _, rep := range reps {
-		loc := rep.ContentLocation()
-		l.Representations = append(l.Representations, Metadata{
-			ContentType:     rep.ContentType(),
-			ContentLanguage: rep.ContentLanguage(),
-			ContentEncoding: rep.ContentEncoding(),
-			ContentLocation: (&loc).String(),
-			ContentCharset:  rep.ContentCharset(),
-			ContentFeatures: rep.ContentFeatures(),
-			SourceQuality:   rep.SourceQuality(),
-		})
+		l.Representations = append(l.Representations, metadataOf(rep))
+	}
+}
+
+// metadataOf describes the provided representation.
+func metadataOf(rep Representation) Metadata {
+	loc := rep.ContentLocation()
+	return Metadata{
+		ContentType:     rep.ContentType(),
+		ContentLanguage: rep.ContentLanguage(),
+		ContentEncoding: rep.ContentEncoding(),
+		ContentLocation: (&loc).String(),
+		ContentCharset:  rep.ContentCharset(),
+		ContentFeatures: rep.ContentFeatures(),
+		SourceQuality:   rep.SourceQuality(),
 	}
 }
 
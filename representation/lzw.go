@@ -0,0 +1,432 @@
+/* Copyright 2020 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package representation
+
+import (
+	"bufio"
+	"errors"
+	"io"
+)
+
+// The "compress" content coding is the adaptive Lempel-Ziv-Welch format
+// produced by the UNIX compress program, commonly known by its .Z file
+// extension. Codes are packed least significant bit first, starting at 9 bits
+// and widening as the dictionary grows up to the maximum code width declared
+// in the header. Whenever the code width changes, or the dictionary is
+// cleared, the remainder of the current group of eight codes is padded.
+const (
+	lzwMagic0        = 0x1f
+	lzwMagic1        = 0x9d
+	lzwBitsMask      = 0x1f
+	lzwReservedMask  = 0x60
+	lzwBlockMode     = 0x80
+	lzwInitBits      = 9
+	lzwMaxBits       = 16
+	lzwClear         = 256
+	lzwFirst         = 257
+	lzwCheckGap      = 10000
+	lzwCodesPerGroup = 8
+)
+
+var (
+	// errLZWHeader indicates the content does not begin with a valid
+	// compress header.
+	errLZWHeader = errors.New("representation compress content has an invalid header")
+
+	// errLZWCorrupt indicates the compress content is corrupt.
+	errLZWCorrupt = errors.New("representation compress content is corrupt")
+
+	// errLZWClosed indicates a write to a closed compress writer.
+	errLZWClosed = errors.New("representation compress writer is closed")
+)
+
+// lzwMaxCode determines the largest free code the decoder tolerates before
+// widening codes beyond the provided width.
+func lzwMaxCode(width, maxBits uint) uint32 {
+	if width == maxBits {
+		return 1 << maxBits
+	}
+	return 1<<width - 1
+}
+
+// lzwWriter is a closeable writer that applies the compress content coding.
+type lzwWriter struct {
+	w   io.Writer
+	buf []byte
+	err error
+
+	// bit packing.
+	acc   uint64
+	nacc  uint
+	width uint
+	codes int
+
+	// dictionary.
+	table map[uint32]uint32
+	next  uint32
+	ent   int32
+
+	// the state of the decoder, which lags the dictionary by one code.
+	free    uint32
+	maxcode uint32
+	first   bool
+
+	// adaptive reset.
+	in         int64
+	out        int64
+	checkpoint int64
+	ratio      int64
+
+	wroteHeader bool
+	closed      bool
+}
+
+// newLZWWriter constructs a writer that applies the compress content coding
+// to the content written to the provided writer.
+func newLZWWriter(w io.Writer) *lzwWriter {
+	lw := &lzwWriter{w: w, ent: -1, first: true, checkpoint: lzwCheckGap}
+	lw.reset()
+	return lw
+}
+
+// reset clears the dictionary and restores the initial code width.
+func (lw *lzwWriter) reset() {
+	lw.table = make(map[uint32]uint32)
+	lw.next = lzwFirst
+	lw.width = lzwInitBits
+	lw.maxcode = lzwMaxCode(lzwInitBits, lzwMaxBits)
+	lw.codes = 0
+	if lw.first {
+		lw.free = lzwFirst
+	} else {
+		lw.free = lzwFirst - 1
+	}
+}
+
+// Write compresses the provided bytes.
+func (lw *lzwWriter) Write(p []byte) (int, error) {
+	if lw.closed {
+		return 0, errLZWClosed
+	}
+	if lw.err != nil {
+		return 0, lw.err
+	}
+	lw.header()
+	for _, c := range p {
+		lw.in++
+		if lw.ent < 0 {
+			lw.ent = int32(c)
+			continue
+		}
+		key := uint32(lw.ent)<<8 | uint32(c)
+		if code, ok := lw.table[key]; ok {
+			lw.ent = int32(code)
+			continue
+		}
+		lw.emit(uint32(lw.ent))
+		lw.ent = int32(c)
+		if lw.next < 1<<lzwMaxBits {
+			lw.table[key] = lw.next
+			lw.next++
+		} else if lw.in >= lw.checkpoint {
+			lw.adapt()
+		}
+	}
+	lw.flush()
+	if lw.err != nil {
+		return 0, lw.err
+	}
+	return len(p), nil
+}
+
+// Close compresses any buffered content and writes the remaining codes. It
+// does not close the underlying writer.
+func (lw *lzwWriter) Close() error {
+	if lw.closed {
+		return lw.err
+	}
+	lw.closed = true
+	lw.header()
+	if lw.ent >= 0 {
+		lw.emit(uint32(lw.ent))
+		lw.ent = -1
+	}
+	if lw.nacc > 0 {
+		lw.buf = append(lw.buf, byte(lw.acc))
+		lw.acc, lw.nacc = 0, 0
+	}
+	lw.flush()
+	return lw.err
+}
+
+// header buffers the compress header, if it hasn't been already.
+func (lw *lzwWriter) header() {
+	if !lw.wroteHeader {
+		lw.buf = append(lw.buf, lzwMagic0, lzwMagic1, lzwBlockMode|lzwMaxBits)
+		lw.wroteHeader = true
+	}
+}
+
+// adapt clears the dictionary once it is full and the compression ratio
+// begins to decline.
+func (lw *lzwWriter) adapt() {
+	lw.checkpoint = lw.in + lzwCheckGap
+	out := lw.out + int64(len(lw.buf))
+	if out == 0 {
+		return
+	}
+	if ratio := (lw.in << 8) / out; ratio > lw.ratio {
+		lw.ratio = ratio
+		return
+	}
+	lw.ratio = 0
+	lw.emit(lzwClear)
+	lw.pad()
+	lw.reset()
+}
+
+// emit buffers the provided code, widening codes when the decoder would.
+func (lw *lzwWriter) emit(code uint32) {
+	if lw.free > lw.maxcode {
+		lw.pad()
+		lw.width++
+		lw.maxcode = lzwMaxCode(lw.width, lzwMaxBits)
+	}
+	lw.acc |= uint64(code) << lw.nacc
+	lw.nacc += lw.width
+	for lw.nacc >= 8 {
+		lw.buf = append(lw.buf, byte(lw.acc))
+		lw.acc >>= 8
+		lw.nacc -= 8
+	}
+	lw.codes++
+	if code == lzwClear {
+		return
+	}
+	if !lw.first && lw.free < 1<<lzwMaxBits {
+		lw.free++
+	}
+	lw.first = false
+}
+
+// pad completes the current group of codes with zero bits.
+func (lw *lzwWriter) pad() {
+	remaining := (lzwCodesPerGroup - lw.codes%lzwCodesPerGroup) % lzwCodesPerGroup
+	for bits := uint(remaining)*lw.width + lw.nacc; bits > 0; bits -= 8 {
+		lw.buf = append(lw.buf, byte(lw.acc))
+		lw.acc >>= 8
+	}
+	lw.acc, lw.nacc, lw.codes = 0, 0, 0
+}
+
+// flush writes the buffered bytes to the underlying writer.
+func (lw *lzwWriter) flush() {
+	if lw.err != nil || len(lw.buf) == 0 {
+		return
+	}
+	var n int
+	n, lw.err = lw.w.Write(lw.buf)
+	lw.out += int64(n)
+	lw.buf = lw.buf[:0]
+}
+
+// lzwReader is a closeable reader that removes the compress content coding.
+type lzwReader struct {
+	r   io.ByteReader
+	err error
+
+	// header.
+	maxBits   uint
+	blockMode bool
+
+	// bit unpacking.
+	acc   uint64
+	nacc  uint
+	width uint
+	codes int
+
+	// dictionary.
+	prefix  []uint16
+	suffix  []byte
+	free    uint32
+	maxcode uint32
+	oldcode int32
+	finchar byte
+
+	stack   []byte
+	pending []byte
+}
+
+// newLZWReader constructs a reader that removes the compress content coding
+// from the content read from the provided reader.
+func newLZWReader(r io.Reader) (*lzwReader, error) {
+	br, ok := r.(io.ByteReader)
+	if !ok {
+		br = bufio.NewReader(r)
+	}
+	var h [3]byte
+	for i := range h {
+		b, err := br.ReadByte()
+		if err != nil {
+			return nil, errLZWHeader
+		}
+		h[i] = b
+	}
+	maxBits := uint(h[2] & lzwBitsMask)
+	if h[0] != lzwMagic0 || h[1] != lzwMagic1 || h[2]&lzwReservedMask != 0 ||
+		maxBits < lzwInitBits || maxBits > lzwMaxBits {
+		return nil, errLZWHeader
+	}
+	lr := &lzwReader{
+		r:         br,
+		maxBits:   maxBits,
+		blockMode: h[2]&lzwBlockMode != 0,
+		width:     lzwInitBits,
+		maxcode:   lzwMaxCode(lzwInitBits, maxBits),
+		prefix:    make([]uint16, 1<<maxBits),
+		suffix:    make([]byte, 1<<maxBits),
+		oldcode:   -1,
+		free:      lzwFirst - 1,
+	}
+	if lr.blockMode {
+		lr.free = lzwFirst
+	}
+	for c := 0; c < 256; c++ {
+		lr.suffix[c] = byte(c)
+	}
+	return lr, nil
+}
+
+// Read decompresses content into the provided bytes.
+func (lr *lzwReader) Read(p []byte) (int, error) {
+	for len(lr.pending) == 0 {
+		if lr.err != nil {
+			return 0, lr.err
+		}
+		lr.decode()
+	}
+	n := copy(p, lr.pending)
+	lr.pending = lr.pending[n:]
+	return n, nil
+}
+
+// Close releases the reader. It does not close the underlying reader.
+func (lr *lzwReader) Close() error {
+	return nil
+}
+
+// decode decodes the next code into the pending bytes.
+func (lr *lzwReader) decode() {
+	if lr.free > lr.maxcode {
+		if !lr.skip() {
+			return
+		}
+		lr.width++
+		lr.maxcode = lzwMaxCode(lr.width, lr.maxBits)
+	}
+	code, ok := lr.read()
+	if !ok {
+		return
+	}
+	if lr.oldcode < 0 {
+		if code >= 256 {
+			lr.err = errLZWCorrupt
+			return
+		}
+		lr.oldcode, lr.finchar = int32(code), byte(code)
+		lr.pending = append(lr.pending[:0], lr.finchar)
+		return
+	}
+	if code == lzwClear && lr.blockMode {
+		if !lr.skip() {
+			return
+		}
+		lr.free = lzwFirst - 1
+		lr.width = lzwInitBits
+		lr.maxcode = lzwMaxCode(lzwInitBits, lr.maxBits)
+		return
+	}
+
+	incode := code
+	lr.stack = lr.stack[:0]
+	if code >= lr.free {
+		if code > lr.free {
+			lr.err = errLZWCorrupt
+			return
+		}
+		// the code is being defined by this very sequence.
+		lr.stack = append(lr.stack, lr.finchar)
+		code = uint32(lr.oldcode)
+	}
+	for code >= 256 {
+		lr.stack = append(lr.stack, lr.suffix[code])
+		code = uint32(lr.prefix[code])
+	}
+	lr.finchar = byte(code)
+	lr.stack = append(lr.stack, lr.finchar)
+	lr.pending = lr.pending[:0]
+	for i := len(lr.stack) - 1; i >= 0; i-- {
+		lr.pending = append(lr.pending, lr.stack[i])
+	}
+	if lr.free < 1<<lr.maxBits {
+		lr.prefix[lr.free] = uint16(lr.oldcode)
+		lr.suffix[lr.free] = lr.finchar
+		lr.free++
+	}
+	lr.oldcode = int32(incode)
+}
+
+// read reads the next code, reporting if one was available.
+func (lr *lzwReader) read() (uint32, bool) {
+	for lr.nacc < lr.width {
+		b, err := lr.r.ReadByte()
+		if err != nil {
+			lr.fail(err)
+			return 0, false
+		}
+		lr.acc |= uint64(b) << lr.nacc
+		lr.nacc += 8
+	}
+	code := uint32(lr.acc & (1<<lr.width - 1))
+	lr.acc >>= lr.width
+	lr.nacc -= lr.width
+	lr.codes++
+	return code, true
+}
+
+// skip discards the padding that completes the current group of codes,
+// reporting if the content continues afterwards.
+func (lr *lzwReader) skip() bool {
+	remaining := (lzwCodesPerGroup - lr.codes%lzwCodesPerGroup) % lzwCodesPerGroup
+	bits := uint(remaining)*lr.width - lr.nacc
+	lr.acc, lr.nacc, lr.codes = 0, 0, 0
+	for ; bits > 0; bits -= 8 {
+		if _, err := lr.r.ReadByte(); err != nil {
+			lr.fail(err)
+			return false
+		}
+	}
+	return true
+}
+
+// fail records the provided read error, where running out of content
+// between codes marks the end of the content.
+func (lr *lzwReader) fail(err error) {
+	if err == io.ErrUnexpectedEOF {
+		err = io.EOF
+	}
+	lr.err = err
+}
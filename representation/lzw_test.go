@@ -0,0 +1,195 @@
+/* Copyright 2020 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package representation
+
+import (
+	"bytes"
+	"io"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// fixtures are .Z files alongside their uncompressed content, both of which
+// reside in the testdata directory.
+var fixtures = []string{"gettysburg.txt", "license.txt"}
+
+type LZWTestSuite struct {
+	suite.Suite
+}
+
+func TestLZWTestSuite(t *testing.T) {
+	suite.Run(t, new(LZWTestSuite))
+}
+
+func (s LZWTestSuite) fixture(name string) (content, compressed []byte) {
+	content, err := os.ReadFile(filepath.Join("testdata", name))
+	s.Require().NoError(err)
+	compressed, err = os.ReadFile(filepath.Join("testdata", name+".Z"))
+	s.Require().NoError(err)
+	return content, compressed
+}
+
+func (s LZWTestSuite) compress(content []byte) []byte {
+	var buf bytes.Buffer
+	w := newLZWWriter(&buf)
+	_, err := w.Write(content)
+	s.Require().NoError(err)
+	s.Require().NoError(w.Close())
+	return buf.Bytes()
+}
+
+func (s LZWTestSuite) uncompress(compressed []byte) ([]byte, error) {
+	r, err := newLZWReader(bytes.NewReader(compressed))
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	return io.ReadAll(r)
+}
+
+func (s LZWTestSuite) TestLZW_Reader_Fixtures() {
+	for _, name := range fixtures {
+		s.Run(name, func() {
+			// arrange.
+			content, compressed := s.fixture(name)
+
+			// action.
+			actual, err := s.uncompress(compressed)
+
+			// assert.
+			s.Require().NoError(err)
+			s.Equal(string(content), string(actual))
+		})
+	}
+}
+
+func (s LZWTestSuite) TestLZW_Writer_Fixtures() {
+	for _, name := range fixtures {
+		s.Run(name, func() {
+			// arrange.
+			content, compressed := s.fixture(name)
+
+			// action.
+			actual := s.compress(content)
+
+			// assert.
+			s.Equal(compressed, actual)
+		})
+	}
+}
+
+func (s LZWTestSuite) TestLZW_RoundTrip() {
+	// arrange.
+	rng := rand.New(rand.NewSource(1))
+	words := []string{"accept", "charset", "encoding", "language", "variant", " ", ",", "\n"}
+	var text bytes.Buffer
+	for text.Len() < 1<<20 {
+		text.WriteString(words[rng.Intn(len(words))])
+	}
+	random := make([]byte, 1<<18)
+	rng.Read(random)
+	tests := []struct {
+		name    string
+		content []byte
+	}{
+		{"Empty", nil},
+		{"SingleByte", []byte("a")},
+		{"Repetitive", bytes.Repeat([]byte("a"), 100000)},
+		{"Text", text.Bytes()},
+		// random content exhausts the dictionary, which is then cleared.
+		{"Random", random},
+		{"TextThenRandom", append(append([]byte{}, text.Bytes()...), random...)},
+	}
+	for _, test := range tests {
+		s.Run(test.name, func() {
+			// arrange.
+			compressed := s.compress(test.content)
+
+			// action.
+			actual, err := s.uncompress(compressed)
+
+			// assert.
+			s.Require().NoError(err)
+			s.Equal(len(test.content), len(actual))
+			s.True(bytes.Equal(test.content, actual))
+		})
+	}
+}
+
+func (s LZWTestSuite) TestLZW_Writer_Header() {
+	// action.
+	compressed := s.compress(nil)
+
+	// assert.
+	s.Equal([]byte{0x1f, 0x9d, 0x90}, compressed)
+}
+
+func (s LZWTestSuite) TestLZW_Writer_Closed() {
+	// arrange.
+	w := newLZWWriter(io.Discard)
+	s.Require().NoError(w.Close())
+
+	// action.
+	_, err := w.Write([]byte("a"))
+
+	// assert.
+	s.ErrorIs(err, errLZWClosed)
+}
+
+func (s LZWTestSuite) TestLZW_Reader_Errors() {
+	tests := []struct {
+		name       string
+		compressed []byte
+		err        error
+	}{
+		{"Empty", nil, errLZWHeader},
+		{"TruncatedHeader", []byte{0x1f, 0x9d}, errLZWHeader},
+		{"InvalidMagic", []byte{0x1f, 0x8b, 0x90}, errLZWHeader},
+		{"ReservedFlags", []byte{0x1f, 0x9d, 0xf0}, errLZWHeader},
+		{"MaxBitsTooSmall", []byte{0x1f, 0x9d, 0x88}, errLZWHeader},
+		{"MaxBitsTooLarge", []byte{0x1f, 0x9d, 0x91}, errLZWHeader},
+		// the first code must be a literal.
+		{"InvalidFirstCode", []byte{0x1f, 0x9d, 0x90, 0x01, 0x01}, errLZWCorrupt},
+		// the second code refers to an entry that isn't yet defined.
+		{"UndefinedCode", []byte{0x1f, 0x9d, 0x90, 0x61, 0x04, 0x02}, errLZWCorrupt},
+	}
+	for _, test := range tests {
+		s.Run(test.name, func() {
+			// action.
+			_, err := s.uncompress(test.compressed)
+
+			// assert.
+			s.ErrorIs(err, test.err)
+		})
+	}
+}
+
+func (s LZWTestSuite) TestLZW_Reader_NonBlockMode() {
+	// arrange - "aaa" encoded as 'a' followed by the first free code, 256,
+	// without the dictionary reset code reserved by block mode.
+	compressed := []byte{0x1f, 0x9d, 0x10, 0x61, 0x00, 0x02}
+
+	// action.
+	actual, err := s.uncompress(compressed)
+
+	// assert.
+	s.Require().NoError(err)
+	s.Equal("aaa", string(actual))
+}
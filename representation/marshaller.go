@@ -15,5 +15,40 @@
 
 package representation
 
+import (
+	"encoding/json"
+	"encoding/xml"
+	"io"
+
+	"gopkg.in/yaml.v2"
+)
+
 // Marshaller represents a marshaling function.
 type Marshaller func(interface{}) ([]byte, error)
+
+// StreamMarshaller represents a marshaling function that writes the
+// serialized form directly to the provided writer. The serialized form may
+// differ from that of the corresponding Marshaller in insignificant
+// whitespace.
+type StreamMarshaller func(io.Writer, interface{}) error
+
+var (
+	// streamJSON serializes the provided value as JSON to the provided writer.
+	streamJSON StreamMarshaller = func(w io.Writer, v interface{}) error {
+		return json.NewEncoder(w).Encode(v)
+	}
+
+	// streamXML serializes the provided value as XML to the provided writer.
+	streamXML StreamMarshaller = func(w io.Writer, v interface{}) error {
+		return xml.NewEncoder(w).Encode(v)
+	}
+
+	// streamYAML serializes the provided value as YAML to the provided writer.
+	streamYAML StreamMarshaller = func(w io.Writer, v interface{}) error {
+		e := yaml.NewEncoder(w)
+		if err := e.Encode(v); err != nil {
+			return err
+		}
+		return e.Close()
+	}
+)
@@ -0,0 +1,133 @@
+/* Copyright 2020 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package representation
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"io"
+	"net/http"
+)
+
+// The media types of problem details representations.
+const (
+	// ProblemJSON is the media type of problem details represented in JSON.
+	ProblemJSON = "application/problem+json"
+
+	// ProblemXML is the media type of problem details represented in XML.
+	ProblemXML = "application/problem+xml"
+)
+
+var (
+	problemMarshallers = map[string]Marshaller{
+		ProblemJSON: json.Marshal,
+		ProblemXML:  xml.Marshal,
+	}
+
+	problemStreamMarshallers = map[string]StreamMarshaller{
+		ProblemJSON: streamJSON,
+		ProblemXML:  streamXML,
+	}
+
+	problemUnmarshallers = map[string]Unmarshaller{
+		ProblemJSON: json.Unmarshal,
+		ProblemXML:  xml.Unmarshal,
+	}
+)
+
+// Problem represents the details of an error encountered while handling a
+// request, as defined in RFC 9457. It is represented in either JSON
+// (application/problem+json) or XML (application/problem+xml).
+//
+// In addition to the members defined in RFC 9457, problems can describe the
+// representations available for the resource with the variants extension
+// member, such as when none of them are acceptable.
+type Problem struct {
+	Base
+
+	XMLName  xml.Name   `json:"-" xml:"urn:ietf:rfc:7807 problem"`
+	Type     string     `json:"type,omitempty" xml:"type,omitempty"`
+	Title    string     `json:"title,omitempty" xml:"title,omitempty"`
+	Status   int        `json:"status,omitempty" xml:"status,omitempty"`
+	Detail   string     `json:"detail,omitempty" xml:"detail,omitempty"`
+	Instance string     `json:"instance,omitempty" xml:"instance,omitempty"`
+	Variants []Metadata `json:"variants,omitempty" xml:"variants>i,omitempty"`
+}
+
+// NewProblem constructs a problem with the provided media type describing
+// an error with the provided HTTP status code. The problem type defaults to
+// "about:blank", with the title being the HTTP status text.
+func NewProblem(mediaType string, status int) *Problem {
+	p := Problem{Type: "about:blank", Title: http.StatusText(status), Status: status}
+	p.SetContentType(mediaType)
+	p.SetContentCharset("utf-8")
+	p.SetContentEncoding([]string{"identity"})
+	p.SetContentLanguage("en-US")
+	p.SetSourceQuality(SourceQualityPerfect)
+	p.SetMarshallers(problemMarshallers)
+	p.SetStreamMarshallers(problemStreamMarshallers)
+	p.SetUnmarshallers(problemUnmarshallers)
+	return &p
+}
+
+// SetVariants modifies the representations described by the variants
+// extension member of the problem.
+func (p *Problem) SetVariants(reps ...Representation) {
+	p.Variants = nil
+	for _, rep := range reps {
+		p.Variants = append(p.Variants, metadataOf(rep))
+	}
+}
+
+// Bytes retrieves the serialized form of the problem.
+func (p Problem) Bytes() ([]byte, error) {
+	return p.Base.Bytes(&p)
+}
+
+// WriteTo writes the serialized form of the problem to the provided writer.
+func (p Problem) WriteTo(w io.Writer) (int64, error) {
+	return p.Base.Stream(w, &p)
+}
+
+// FromBytes constructs the problem from its serialized form.
+func (p *Problem) FromBytes(b []byte) error {
+	return p.Base.FromBytes(b, p)
+}
+
+// NotAcceptableProblem constructs problems with the provided media type
+// describing a 406 Not Acceptable error, where the provided representations
+// are described as the available variants. The constructor can be negotiated
+// like any other list representation constructor.
+func NotAcceptableProblem(mediaType string) ListConstructor {
+	return func(reps ...Representation) Representation {
+		p := NewProblem(mediaType, http.StatusNotAcceptable)
+		p.Detail = "none of the available representations are acceptable"
+		p.SetVariants(reps...)
+		return p
+	}
+}
+
+// VariantAlsoNegotiatesProblem constructs problems with the provided media
+// type describing a 506 Variant Also Negotiates error, where the provided
+// representations are described as the variants of the resource.
+func VariantAlsoNegotiatesProblem(mediaType string) ListConstructor {
+	return func(reps ...Representation) Representation {
+		p := NewProblem(mediaType, http.StatusVariantAlsoNegotiates)
+		p.Detail = "a variant of the resource is itself negotiable"
+		p.SetVariants(reps...)
+		return p
+	}
+}
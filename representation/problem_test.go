@@ -0,0 +1,187 @@
+/* Copyright 2020 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package representation_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/freerware/negotiator/representation"
+	"github.com/stretchr/testify/suite"
+)
+
+type ProblemTestSuite struct {
+	suite.Suite
+}
+
+func TestProblemTestSuite(t *testing.T) {
+	suite.Run(t, new(ProblemTestSuite))
+}
+
+func (s ProblemTestSuite) variant() representation.Representation {
+	reps, err := representation.Of(
+		thing{ID: 1, Name: "one"},
+		representation.MediaTypes("application/json"),
+		representation.Language("en-US"),
+	)
+	s.Require().NoError(err)
+	reps[0].(*representation.Value[thing]).SetContentLocation(url.URL{Path: "/things/1"})
+	return reps[0]
+}
+
+func (s ProblemTestSuite) TestNewProblem() {
+	// action.
+	p := representation.NewProblem(representation.ProblemJSON, http.StatusBadRequest)
+
+	// assert.
+	s.Equal("about:blank", p.Type)
+	s.Equal(http.StatusText(http.StatusBadRequest), p.Title)
+	s.Equal(http.StatusBadRequest, p.Status)
+	s.Equal(representation.ProblemJSON, p.ContentType())
+	s.Equal("utf-8", p.ContentCharset())
+	s.Equal("en-US", p.ContentLanguage())
+}
+
+func (s ProblemTestSuite) TestProblem_Bytes_JSON() {
+	// arrange.
+	p := representation.NewProblem(representation.ProblemJSON, http.StatusNotFound)
+	p.Detail = "thing 42 does not exist"
+	p.Instance = "/things/42"
+
+	// action.
+	b, err := p.Bytes()
+
+	// assert.
+	s.Require().NoError(err)
+	s.JSONEq(`{
+		"type": "about:blank",
+		"title": "Not Found",
+		"status": 404,
+		"detail": "thing 42 does not exist",
+		"instance": "/things/42"
+	}`, string(b))
+}
+
+func (s ProblemTestSuite) TestProblem_Bytes_XML() {
+	// arrange.
+	p := representation.NewProblem(representation.ProblemXML, http.StatusNotAcceptable)
+	p.SetVariants(s.variant())
+
+	// action.
+	b, err := p.Bytes()
+
+	// assert.
+	s.Require().NoError(err)
+	s.Equal(
+		`<problem xmlns="urn:ietf:rfc:7807">`+
+			`<type>about:blank</type>`+
+			`<title>Not Acceptable</title>`+
+			`<status>406</status>`+
+			`<variants><i>`+
+			`<ContentType>application/json</ContentType>`+
+			`<ContentLanguage>en-US</ContentLanguage>`+
+			`<ContentLocation>/things/1</ContentLocation>`+
+			`<ContentCharset>utf-8</ContentCharset>`+
+			`<SourceQuality>1</SourceQuality>`+
+			`</i></variants>`+
+			`</problem>`,
+		string(b),
+	)
+}
+
+func (s ProblemTestSuite) TestProblem_FromBytes() {
+	for _, mediaType := range []string{representation.ProblemJSON, representation.ProblemXML} {
+		s.Run(mediaType, func() {
+			// arrange.
+			expected := representation.NewProblem(mediaType, http.StatusNotAcceptable)
+			expected.Detail = "whoa"
+			expected.SetVariants(s.variant())
+			b, err := expected.Bytes()
+			s.Require().NoError(err)
+			actual := representation.NewProblem(mediaType, http.StatusOK)
+
+			// action.
+			err = actual.FromBytes(b)
+
+			// assert.
+			s.Require().NoError(err)
+			s.Equal(expected.Type, actual.Type)
+			s.Equal(expected.Title, actual.Title)
+			s.Equal(expected.Status, actual.Status)
+			s.Equal(expected.Detail, actual.Detail)
+			s.Equal(expected.Variants, actual.Variants)
+		})
+	}
+}
+
+func (s ProblemTestSuite) TestProblem_WriteTo() {
+	// arrange.
+	p := representation.NewProblem(representation.ProblemJSON, http.StatusBadRequest)
+	var buf bytes.Buffer
+
+	// action.
+	n, err := p.WriteTo(&buf)
+
+	// assert.
+	s.Require().NoError(err)
+	s.Equal(int64(buf.Len()), n)
+	s.JSONEq(`{"type":"about:blank","title":"Bad Request","status":400}`, buf.String())
+}
+
+func (s ProblemTestSuite) TestNotAcceptableProblem() {
+	// arrange.
+	constructor := representation.NotAcceptableProblem(representation.ProblemJSON)
+
+	// action.
+	rep := constructor(s.variant())
+
+	// assert.
+	s.Equal(representation.ProblemJSON, rep.ContentType())
+	b, err := rep.Bytes()
+	s.Require().NoError(err)
+	var body map[string]interface{}
+	s.Require().NoError(json.Unmarshal(b, &body))
+	s.Equal(float64(http.StatusNotAcceptable), body["status"])
+	s.Equal("Not Acceptable", body["title"])
+	s.Equal([]interface{}{map[string]interface{}{
+		"contentType":     "application/json",
+		"contentLanguage": "en-US",
+		"contentLocation": "/things/1",
+		"contentCharset":  "utf-8",
+		"sourceQuality":   float64(1),
+	}}, body["variants"])
+}
+
+func (s ProblemTestSuite) TestVariantAlsoNegotiatesProblem() {
+	// arrange.
+	constructor := representation.VariantAlsoNegotiatesProblem(representation.ProblemJSON)
+
+	// action.
+	rep := constructor(s.variant())
+
+	// assert.
+	s.Equal(representation.ProblemJSON, rep.ContentType())
+	b, err := rep.Bytes()
+	s.Require().NoError(err)
+	var body map[string]interface{}
+	s.Require().NoError(json.Unmarshal(b, &body))
+	s.Equal(float64(http.StatusVariantAlsoNegotiates), body["status"])
+	s.Equal("Variant Also Negotiates", body["title"])
+	s.Len(body["variants"], 1)
+}
@@ -18,7 +18,6 @@ package representation
 import (
 	"compress/flate"
 	"compress/gzip"
-	"compress/zlib"
 	"io"
 )
 
@@ -29,7 +28,9 @@ var (
 	}
 
 	// newCompressReader is the constructor for the compress closeable reader.
-	newCompressReader EncodingReaderConstructor = zlib.NewReader
+	newCompressReader EncodingReaderConstructor = func(r io.Reader) (io.ReadCloser, error) {
+		return newLZWReader(r)
+	}
 
 	// newDeflateReader is the constructor for the deflate closeable reader.
 	newDeflateReader EncodingReaderConstructor = func(r io.Reader) (io.ReadCloser, error) {
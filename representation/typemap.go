@@ -0,0 +1,216 @@
+/* Copyright 2020 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package representation
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io/fs"
+	"mime"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalidTypeMap is an error that indicates that a type map is malformed.
+var ErrInvalidTypeMap = errors.New("type map is invalid")
+
+// Type map headers that describe the variants of a resource.
+const (
+	typeMapURI             = "uri"
+	typeMapContentType     = "content-type"
+	typeMapContentLanguage = "content-language"
+	typeMapContentEncoding = "content-encoding"
+	typeMapContentLength   = "content-length"
+	typeMapDescription     = "description"
+)
+
+// ParseTypeMapFile parses the type map at the provided path, resolving the
+// variants relative to the directory containing it.
+func ParseTypeMapFile(name string) ([]Representation, error) {
+	return ParseTypeMapFS(os.DirFS(filepath.Dir(name)), filepath.Base(name))
+}
+
+// ParseTypeMapFS parses the type map with the provided name within the
+// provided file system, producing a representation for each variant it
+// describes whose content is that of the file referenced by the URI of the
+// variant. As with Apache httpd, records without any headers besides the URI,
+// such as the record describing the resource itself, are ignored.
+//
+// https://httpd.apache.org/docs/2.4/mod/mod_negotiation.html#typemaps
+func ParseTypeMapFS(fsys fs.FS, name string) ([]Representation, error) {
+	f, err := fsys.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var (
+		records []typeMapRecord
+		record  typeMapRecord
+		scanner = bufio.NewScanner(f)
+	)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimRight(scanner.Text(), "\r")
+		switch {
+		case len(strings.TrimSpace(text)) == 0:
+			if len(record) > 0 {
+				records = append(records, record)
+				record = nil
+			}
+		case text[0] == ' ' || text[0] == '\t':
+			// continuation lines extend the value of the preceding header.
+			if len(record) == 0 {
+				return nil, invalidTypeMap(name, line, "continuation line without header")
+			}
+			h := &record[len(record)-1]
+			h.value = h.value + " " + strings.TrimSpace(text)
+		default:
+			n, v, ok := strings.Cut(text, ":")
+			if !ok {
+				return nil, invalidTypeMap(name, line, "missing ':' in %q", text)
+			}
+			record = append(record, typeMapHeader{
+				name:  strings.ToLower(strings.TrimSpace(n)),
+				value: strings.TrimSpace(v),
+				line:  line,
+			})
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(record) > 0 {
+		records = append(records, record)
+	}
+
+	var reps []Representation
+	for _, r := range records {
+		rep, err := r.representation(fsys, name)
+		if err != nil {
+			return nil, err
+		}
+		if rep != nil {
+			reps = append(reps, rep)
+		}
+	}
+	return reps, nil
+}
+
+// typeMapHeader represents a header within a type map record.
+type typeMapHeader struct {
+	name  string
+	value string
+	line  int
+}
+
+// typeMapRecord represents the headers describing a single variant within a
+// type map.
+type typeMapRecord []typeMapHeader
+
+// representation constructs the representation of the variant described by
+// the record within the type map with the provided name, which is nil when the
+// record does not describe a variant.
+func (r typeMapRecord) representation(fsys fs.FS, name string) (*File, error) {
+	var (
+		uri        *url.URL
+		hasContent bool
+	)
+	for _, h := range r {
+		switch h.name {
+		case typeMapURI:
+			u, err := url.Parse(h.value)
+			if err != nil || u.Scheme != "" || u.Host != "" || u.Path == "" || path.IsAbs(u.Path) {
+				return nil, invalidTypeMap(name, h.line, "invalid URI %q", h.value)
+			}
+			uri = u
+		case typeMapContentType,
+			typeMapContentLanguage,
+			typeMapContentEncoding,
+			typeMapContentLength,
+			typeMapDescription:
+			hasContent = true
+		}
+	}
+	if uri == nil || !hasContent {
+		return nil, nil
+	}
+
+	file := path.Join(path.Dir(name), uri.Path)
+	if !fs.ValidPath(file) {
+		return nil, invalidTypeMap(name, r[0].line, "URI %q is outside of the file system", uri.String())
+	}
+	rep := NewFile(fsys, file)
+	rep.SetContentLocation(*uri)
+	rep.SetSourceQuality(1.0)
+	for _, h := range r {
+		switch h.name {
+		case typeMapContentType:
+			mediaType, params, err := mime.ParseMediaType(h.value)
+			if err != nil {
+				return nil, invalidTypeMap(name, h.line, "invalid content type %q", h.value)
+			}
+			if qs, ok := params["qs"]; ok {
+				sq, err := strconv.ParseFloat(qs, 32)
+				if err != nil || sq < 0 || sq > 1 {
+					return nil, invalidTypeMap(name, h.line, "invalid source quality %q", qs)
+				}
+				rep.SetSourceQuality(float32(sq))
+				delete(params, "qs")
+			}
+			if charset, ok := params["charset"]; ok {
+				rep.SetContentCharset(charset)
+			}
+			rep.SetContentType(mime.FormatMediaType(mediaType, params))
+		case typeMapContentLanguage:
+			rep.SetContentLanguage(strings.Join(typeMapList(h.value), ", "))
+		case typeMapContentEncoding:
+			rep.SetContentEncoding(typeMapList(strings.ToLower(h.value)))
+		case typeMapContentLength:
+			length, err := strconv.Atoi(h.value)
+			if err != nil || length < 0 {
+				return nil, invalidTypeMap(name, h.line, "invalid content length %q", h.value)
+			}
+			rep.SetContentLength(length)
+		case typeMapDescription:
+			rep.SetContentDescription(h.value, "")
+		}
+	}
+	return rep, nil
+}
+
+// typeMapList provides the elements of the provided comma separated header
+// value, omitting those that are empty.
+func typeMapList(value string) []string {
+	var elements []string
+	for _, e := range strings.Split(value, ",") {
+		if e = strings.TrimSpace(e); len(e) > 0 {
+			elements = append(elements, e)
+		}
+	}
+	return elements
+}
+
+// invalidTypeMap constructs an error indicating that the type map with the
+// provided name is malformed at the provided line.
+func invalidTypeMap(name string, line int, format string, args ...interface{}) error {
+	cause := fmt.Sprintf(format, args...)
+	return fmt.Errorf("%w: %s:%d: %s", ErrInvalidTypeMap, name, line, cause)
+}
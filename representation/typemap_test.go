@@ -0,0 +1,194 @@
+/* Copyright 2020 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package representation_test
+
+import (
+	"bytes"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/freerware/negotiator/representation"
+	"github.com/stretchr/testify/suite"
+)
+
+type TypeMapTestSuite struct {
+	suite.Suite
+}
+
+func TestTypeMapTestSuite(t *testing.T) {
+	suite.Run(t, new(TypeMapTestSuite))
+}
+
+// typeMap is a type map in the style of those served by Apache httpd.
+const typeMap = `URI: foo
+
+URI: foo.en.html
+Content-type: text/html; qs=0.8; level=2
+Content-language: en
+Description: English
+  version
+
+URI: foo.fr.de.html
+Content-type: text/html;charset=iso-8859-2
+Content-language: fr, de
+Content-Length: 5
+
+URI: foo.html.gz
+Content-Type: text/html
+Content-Encoding: x-gzip
+`
+
+func (s TypeMapTestSuite) fs() fstest.MapFS {
+	return fstest.MapFS{
+		"site/foo.var":        {Data: []byte(typeMap)},
+		"site/foo.en.html":    {Data: []byte("<p>hello</p>")},
+		"site/foo.fr.de.html": {Data: []byte("salut")},
+		"site/foo.html.gz":    {Data: []byte{0x1f, 0x8b}},
+	}
+}
+
+func (s TypeMapTestSuite) TestParseTypeMapFS() {
+	// action.
+	reps, err := representation.ParseTypeMapFS(s.fs(), "site/foo.var")
+
+	// assert.
+	s.Require().NoError(err)
+	s.Require().Len(reps, 3)
+
+	en := reps[0]
+	location := en.ContentLocation()
+	s.Equal("foo.en.html", location.String())
+	s.Equal("text/html; level=2", en.ContentType())
+	s.Equal("en", en.ContentLanguage())
+	s.Empty(en.ContentCharset())
+	s.Empty(en.ContentEncoding())
+	s.Equal(float32(0.8), en.SourceQuality())
+	text, tag := en.(*representation.File).ContentDescription()
+	s.Equal("English version", text)
+	s.Empty(tag)
+	b, err := en.Bytes()
+	s.Require().NoError(err)
+	s.Equal("<p>hello</p>", string(b))
+
+	multi := reps[1]
+	s.Equal("text/html; charset=iso-8859-2", multi.ContentType())
+	s.Equal("iso-8859-2", multi.ContentCharset())
+	s.Equal("fr, de", multi.ContentLanguage())
+	s.Equal(float32(1.0), multi.SourceQuality())
+	length, err := representation.ContentLength(multi)
+	s.Require().NoError(err)
+	s.Equal(5, length)
+
+	gz := reps[2]
+	s.Equal("site/foo.html.gz", gz.(*representation.File).Name())
+	s.Equal([]string{"x-gzip"}, gz.ContentEncoding())
+	b, err = gz.Bytes()
+	s.Require().NoError(err)
+	s.Equal([]byte{0x1f, 0x8b}, b)
+}
+
+func (s TypeMapTestSuite) TestParseTypeMapFile() {
+	// arrange.
+	dir := s.T().TempDir()
+	for name, file := range s.fs() {
+		s.Require().NoError(os.WriteFile(filepath.Join(dir, filepath.Base(name)), file.Data, 0o600))
+	}
+
+	// action.
+	reps, err := representation.ParseTypeMapFile(filepath.Join(dir, "foo.var"))
+
+	// assert.
+	s.Require().NoError(err)
+	s.Require().Len(reps, 3)
+	b, err := reps[1].Bytes()
+	s.Require().NoError(err)
+	s.Equal("salut", string(b))
+}
+
+func (s TypeMapTestSuite) TestParseTypeMap_Invalid() {
+	tests := []struct {
+		name    string
+		typeMap string
+		err     error
+	}{
+		{"MissingColon", "URI: foo.html\nContent-Type text/html\n", representation.ErrInvalidTypeMap},
+		{"Continuation", " text/html\n", representation.ErrInvalidTypeMap},
+		{"AbsoluteURI", "URI: http://freer.ddns.net/foo.html\nContent-Type: text/html\n", representation.ErrInvalidTypeMap},
+		{"RootedURI", "URI: /foo.html\nContent-Type: text/html\n", representation.ErrInvalidTypeMap},
+		{"OutsideURI", "URI: ../../foo.html\nContent-Type: text/html\n", representation.ErrInvalidTypeMap},
+		{"ContentType", "URI: foo.html\nContent-Type: text/\n", representation.ErrInvalidTypeMap},
+		{"SourceQuality", "URI: foo.html\nContent-Type: text/html; qs=2\n", representation.ErrInvalidTypeMap},
+		{"ContentLength", "URI: foo.html\nContent-Length: -1\n", representation.ErrInvalidTypeMap},
+		{"Missing", "", fs.ErrNotExist},
+	}
+	for _, test := range tests {
+		s.Run(test.name, func() {
+			// arrange.
+			fsys := fstest.MapFS{}
+			if len(test.typeMap) > 0 {
+				fsys["site/foo.var"] = &fstest.MapFile{Data: []byte(test.typeMap)}
+			}
+
+			// action.
+			reps, err := representation.ParseTypeMapFS(fsys, "site/foo.var")
+
+			// assert.
+			s.ErrorIs(err, test.err)
+			s.Nil(reps)
+		})
+	}
+}
+
+func (s TypeMapTestSuite) TestFile() {
+	// arrange.
+	sut := representation.NewFile(s.fs(), "site/foo.en.html")
+	var buf bytes.Buffer
+
+	// action.
+	n, err := sut.WriteTo(&buf)
+
+	// assert.
+	s.Require().NoError(err)
+	s.Equal(int64(12), n)
+	s.Equal("<p>hello</p>", buf.String())
+	length, known := sut.ContentLengthHint()
+	s.True(known)
+	s.Equal(12, length)
+	s.ErrorIs(sut.FromBytes([]byte("thing")), representation.ErrReadOnly)
+	_, known = representation.NewFile(s.fs(), "site/missing.html").ContentLengthHint()
+	s.False(known)
+}
+
+func (s TypeMapTestSuite) TestFile_ContentLength() {
+	// arrange.
+	sut := representation.NewFile(s.fs(), "site/foo.en.html")
+	sut.SetContentLength(5)
+
+	// action.
+	hint, hinted := sut.ContentLengthHint()
+	length, known := sut.ContentLength()
+
+	// assert.
+	s.True(hinted)
+	s.Equal(5, hint)
+	s.True(known)
+	s.Equal(len("<p>hello</p>"), length)
+	_, known = representation.NewFile(s.fs(), "site/missing.html").ContentLength()
+	s.False(known)
+}
@@ -0,0 +1,189 @@
+/* Copyright 2020 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package representation
+
+import (
+	"bytes"
+	"io"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"text/template"
+)
+
+// extensions are the file extensions conventionally associated with the
+// media types that have default marshallers.
+var extensions = map[string]string{
+	"application/json": "json",
+	"application/xml":  "xml",
+	"application/yaml": "yaml",
+	"text/yaml":        "yaml",
+	"text/html":        "html",
+}
+
+// Value represents a representation of an arbitrary value, which is
+// serialized based on the content type of the representation.
+type Value[T any] struct {
+	Base
+
+	value T
+}
+
+// Value retrieves the value being represented.
+func (v Value[T]) Value() T {
+	return v.value
+}
+
+// SetValue modifies the value being represented.
+func (v *Value[T]) SetValue(value T) {
+	v.value = value
+}
+
+// Bytes retrieves the serialized form of the value representation.
+func (v Value[T]) Bytes() ([]byte, error) {
+	return v.Base.Bytes(v.value)
+}
+
+// WriteTo writes the serialized form of the value representation to the
+// provided writer without buffering it.
+func (v Value[T]) WriteTo(w io.Writer) (int64, error) {
+	return v.Base.Stream(w, v.value)
+}
+
+// FromBytes constructs the value representation from its serialized form.
+func (v *Value[T]) FromBytes(b []byte) error {
+	return v.Base.FromBytes(b, &v.value)
+}
+
+// LocationContext represents the information available to templates used
+// to construct the content location of each representation.
+type LocationContext struct {
+	MediaType string
+	Extension string
+	Language  string
+}
+
+// Of constructs a representation of the provided value for each of the
+// media types that have a default marshaller, or the subset of media types
+// specified.
+func Of[T any](value T, options ...OfOption) ([]Representation, error) {
+	return variants(func() variant {
+		return &Value[T]{value: value}
+	}, options...)
+}
+
+// LazyOf constructs a lazy representation for each of the media types that
+// have a default marshaller, or the subset of media types specified. The
+// representations share the provided callback, which is invoked at most once
+// regardless of how many of them are serialized.
+func LazyOf[T any](fn func() (T, error), options ...OfOption) ([]Representation, error) {
+	value := sync.OnceValues(fn)
+	return variants(func() variant {
+		return newLazy(value)
+	}, options...)
+}
+
+// variant represents a representation whose metadata can be modified.
+type variant interface {
+	Representation
+	SetContentType(string)
+	SetContentCharset(string)
+	SetContentLanguage(string)
+	SetContentLocation(url.URL)
+	SetSourceQuality(float32)
+}
+
+// variants constructs a representation with the provided constructor for
+// each of the media types configured by the provided options.
+func variants(construct func() variant, options ...OfOption) ([]Representation, error) {
+	// set defaults.
+	o := OfOptions{
+		Charset:         "utf-8",
+		SourceQualities: map[string]float32{},
+		Languages:       map[string]string{},
+	}
+	for mediaType := range defaultMarshallers {
+		o.MediaTypes = append(o.MediaTypes, mediaType)
+	}
+	sort.Strings(o.MediaTypes)
+	// apply options.
+	for _, opt := range options {
+		opt(&o)
+	}
+
+	var (
+		location *template.Template
+		err      error
+	)
+	if len(o.Location) > 0 {
+		if location, err = template.New("location").Parse(o.Location); err != nil {
+			return nil, err
+		}
+	}
+
+	var reps []Representation
+	for _, mediaType := range o.MediaTypes {
+		mt := strings.ToLower(strings.Split(mediaType, ";")[0])
+		if _, ok := defaultMarshallers[mt]; !ok {
+			return nil, ErrUnsupportedContentType
+		}
+
+		language := o.Language
+		if l, ok := o.Languages[mt]; ok {
+			language = l
+		}
+
+		v := construct()
+		v.SetContentType(mediaType)
+		v.SetContentCharset(o.Charset)
+		v.SetContentLanguage(language)
+		v.SetSourceQuality(SourceQualityPerfect)
+		if qs, ok := o.SourceQualities[mt]; ok {
+			v.SetSourceQuality(qs)
+		}
+		if location != nil {
+			ctx := LocationContext{
+				MediaType: mt,
+				Extension: extension(mt),
+				Language:  language,
+			}
+			var buf bytes.Buffer
+			if err = location.Execute(&buf, ctx); err != nil {
+				return nil, err
+			}
+			var loc *url.URL
+			if loc, err = url.Parse(buf.String()); err != nil {
+				return nil, err
+			}
+			v.SetContentLocation(*loc)
+		}
+		reps = append(reps, v)
+	}
+	return reps, nil
+}
+
+// extension provides the file extension for the provided media type,
+// falling back to the media subtype when there is no conventional extension.
+func extension(mediaType string) string {
+	if ext, ok := extensions[mediaType]; ok {
+		return ext
+	}
+	if idx := strings.LastIndex(mediaType, "/"); idx != -1 {
+		return mediaType[idx+1:]
+	}
+	return mediaType
+}
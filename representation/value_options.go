@@ -0,0 +1,86 @@
+/* Copyright 2020 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package representation
+
+import "strings"
+
+// OfOptions represents the configuration options for constructing
+// representations of a value.
+type OfOptions struct {
+	MediaTypes      []string
+	SourceQualities map[string]float32
+	Language        string
+	Languages       map[string]string
+	Charset         string
+	Location        string
+}
+
+// OfOption represents a configurable option for constructing
+// representations of a value.
+type OfOption func(*OfOptions)
+
+// Options that can be used to configure the representations of a value.
+var (
+	// MediaTypes defines the subset of media types to construct
+	// representations for. Each media type must have a default marshaller.
+	MediaTypes = func(mediaTypes ...string) OfOption {
+		return func(o *OfOptions) {
+			o.MediaTypes = mediaTypes
+		}
+	}
+
+	// SourceQuality defines the source quality of the representation with
+	// the provided media type. Representations have perfect source quality
+	// by default.
+	SourceQuality = func(mediaType string, qs float32) OfOption {
+		return func(o *OfOptions) {
+			o.SourceQualities[strings.ToLower(mediaType)] = qs
+		}
+	}
+
+	// Language defines the language of the representations, unless
+	// defined for the media type of a representation with MediaTypeLanguage.
+	Language = func(language string) OfOption {
+		return func(o *OfOptions) {
+			o.Language = language
+		}
+	}
+
+	// MediaTypeLanguage defines the language of the representation with the
+	// provided media type, which takes precedence over Language.
+	MediaTypeLanguage = func(mediaType, language string) OfOption {
+		return func(o *OfOptions) {
+			o.Languages[strings.ToLower(mediaType)] = language
+		}
+	}
+
+	// Charset defines the charset of the representations, which is "utf-8"
+	// by default.
+	Charset = func(charset string) OfOption {
+		return func(o *OfOptions) {
+			o.Charset = charset
+		}
+	}
+
+	// Location defines the template utilized to construct the content
+	// location of each representation. The template is provided a
+	// LocationContext, such as "/things/1.{{.Extension}}".
+	Location = func(template string) OfOption {
+		return func(o *OfOptions) {
+			o.Location = template
+		}
+	}
+)
@@ -0,0 +1,193 @@
+/* Copyright 2020 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package representation_test
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/freerware/negotiator/representation"
+	"github.com/stretchr/testify/suite"
+)
+
+type thing struct {
+	ID   int    `json:"id" xml:"id" yaml:"id"`
+	Name string `json:"name" xml:"name" yaml:"name"`
+}
+
+type ValueTestSuite struct {
+	suite.Suite
+}
+
+func TestValueTestSuite(t *testing.T) {
+	suite.Run(t, new(ValueTestSuite))
+}
+
+func (s ValueTestSuite) TestOf() {
+	// action.
+	reps, err := representation.Of(thing{ID: 1, Name: "one"})
+
+	// assert.
+	s.Require().NoError(err)
+	var mediaTypes []string
+	for _, rep := range reps {
+		mediaTypes = append(mediaTypes, rep.ContentType())
+		s.Equal("utf-8", rep.ContentCharset())
+		s.Equal(representation.SourceQualityPerfect, rep.SourceQuality())
+	}
+	s.Equal([]string{
+		"application/json",
+		"application/xml",
+		"application/yaml",
+		"text/html",
+		"text/yaml",
+	}, mediaTypes)
+}
+
+func (s ValueTestSuite) TestOf_Bytes() {
+	tests := []struct {
+		mediaType string
+		out       string
+	}{
+		{"application/json", `{"id":1,"name":"one"}`},
+		{"application/xml", "<thing><id>1</id><name>one</name></thing>"},
+		{"application/yaml", "id: 1\nname: one\n"},
+	}
+
+	for _, test := range tests {
+		s.Run(test.mediaType, func() {
+			// arrange.
+			reps, err := representation.Of(
+				thing{ID: 1, Name: "one"},
+				representation.MediaTypes(test.mediaType),
+			)
+			s.Require().NoError(err)
+			s.Require().Len(reps, 1)
+
+			// action.
+			b, err := reps[0].Bytes()
+
+			// assert.
+			s.Require().NoError(err)
+			s.Equal(test.out, string(b))
+		})
+	}
+}
+
+func (s ValueTestSuite) TestValue_WriteTo() {
+	// arrange.
+	reps, err := representation.Of(
+		thing{ID: 1, Name: "one"},
+		representation.MediaTypes("application/json"),
+	)
+	s.Require().NoError(err)
+	wt, ok := reps[0].(io.WriterTo)
+	s.Require().True(ok)
+	var buf bytes.Buffer
+
+	// action.
+	n, err := wt.WriteTo(&buf)
+
+	// assert.
+	s.Require().NoError(err)
+	s.Equal(int64(buf.Len()), n)
+	s.Equal(`{"id":1,"name":"one"}`+"\n", buf.String())
+}
+
+func (s ValueTestSuite) TestOf_FromBytes() {
+	// arrange.
+	reps, err := representation.Of(thing{}, representation.MediaTypes("application/json"))
+	s.Require().NoError(err)
+
+	// action.
+	err = reps[0].FromBytes([]byte(`{"id":2,"name":"two"}`))
+
+	// assert.
+	s.Require().NoError(err)
+	v, ok := reps[0].(*representation.Value[thing])
+	s.Require().True(ok)
+	s.Equal(thing{ID: 2, Name: "two"}, v.Value())
+}
+
+func (s ValueTestSuite) TestOf_Options() {
+	// action.
+	reps, err := representation.Of(
+		thing{ID: 1, Name: "one"},
+		representation.MediaTypes("application/json", "application/xml"),
+		representation.SourceQuality("application/xml", representation.SourceQualityAcceptable),
+		representation.Language("en-US"),
+		representation.Charset("ascii"),
+		representation.Location("/things/1.{{.Extension}}?lang={{.Language}}"),
+	)
+
+	// assert.
+	s.Require().NoError(err)
+	s.Require().Len(reps, 2)
+	json, xml := reps[0], reps[1]
+	s.Equal(representation.SourceQualityPerfect, json.SourceQuality())
+	s.Equal(representation.SourceQualityAcceptable, xml.SourceQuality())
+	for _, rep := range reps {
+		s.Equal("en-US", rep.ContentLanguage())
+		s.Equal("ascii", rep.ContentCharset())
+	}
+	jsonLoc, xmlLoc := json.ContentLocation(), xml.ContentLocation()
+	s.Equal("/things/1.json?lang=en-US", jsonLoc.String())
+	s.Equal("/things/1.xml?lang=en-US", xmlLoc.String())
+}
+
+func (s ValueTestSuite) TestOf_MediaTypeLanguage() {
+	// action.
+	reps, err := representation.Of(
+		thing{ID: 1, Name: "one"},
+		representation.MediaTypes("application/json", "application/xml"),
+		representation.Language("en-US"),
+		representation.MediaTypeLanguage("application/xml", "fr"),
+		representation.Location("/things/1.{{.Extension}}?lang={{.Language}}"),
+	)
+
+	// assert.
+	s.Require().NoError(err)
+	s.Require().Len(reps, 2)
+	json, xml := reps[0], reps[1]
+	s.Equal("en-US", json.ContentLanguage())
+	s.Equal("fr", xml.ContentLanguage())
+	jsonLoc, xmlLoc := json.ContentLocation(), xml.ContentLocation()
+	s.Equal("/things/1.json?lang=en-US", jsonLoc.String())
+	s.Equal("/things/1.xml?lang=fr", xmlLoc.String())
+}
+
+func (s ValueTestSuite) TestOf_Errors() {
+	tests := []struct {
+		name    string
+		options []representation.OfOption
+	}{
+		{"UnsupportedMediaType", []representation.OfOption{representation.MediaTypes("image/png")}},
+		{"InvalidLocationTemplate", []representation.OfOption{representation.Location("{{.Extension")}},
+		{"UnknownLocationField", []representation.OfOption{representation.Location("{{.Whoa}}")}},
+	}
+
+	for _, test := range tests {
+		s.Run(test.name, func() {
+			// action.
+			reps, err := representation.Of(thing{}, test.options...)
+
+			// assert.
+			s.Require().Error(err)
+			s.Nil(reps)
+		})
+	}
+}
@@ -18,7 +18,6 @@ package representation
 import (
 	"compress/flate"
 	"compress/gzip"
-	"compress/zlib"
 	"io"
 )
 
@@ -30,7 +29,7 @@ var (
 
 	// newCompressWriter is the constructor for the compress closeable writer.
 	newCompressWriter EncodingWriterConstructor = func(w io.WriteCloser) (io.WriteCloser, error) {
-		return zlib.NewWriter(w), nil
+		return newLZWWriter(w), nil
 	}
 
 	// newDeflateWriter is the constructor for the default closeable writer.
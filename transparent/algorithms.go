@@ -0,0 +1,145 @@
+/* Copyright 2020 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package transparent
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/freerware/negotiator/header"
+	"github.com/freerware/negotiator/representation"
+)
+
+// rvsa1Version is the version of the Remote Variant Selection Algorithm 1.0.
+const rvsa1Version = "1.0"
+
+// algorithm represents a remote variant selection algorithm registered
+// with its version.
+type algorithm struct {
+	version string
+	major   int
+	minor   int
+	chooser representation.Chooser
+}
+
+// parseVersion parses the provided remote variant selection algorithm
+// version, which consists of a major and minor version number.
+//
+// https://tools.ietf.org/html/rfc2295#section-8.4
+func parseVersion(version string) (major, minor int, ok bool) {
+	parts := strings.Split(version, ".")
+	if len(parts) != 2 {
+		return 0, 0, false
+	}
+	var err error
+	if major, err = strconv.Atoi(parts[0]); err != nil || major < 0 {
+		return 0, 0, false
+	}
+	if minor, err = strconv.Atoi(parts[1]); err != nil || minor < 0 {
+		return 0, 0, false
+	}
+	return major, minor, true
+}
+
+// algorithms represents the registered remote variant selection algorithms,
+// ordered from the highest version to the lowest.
+type algorithms []algorithm
+
+// newAlgorithms constructs the registered algorithms from the provided
+// algorithms keyed on their versions, providing the versions that are
+// invalid separately.
+func newAlgorithms(choosers map[string]representation.Chooser) (algorithms, []string) {
+	var (
+		a       algorithms
+		invalid []string
+	)
+	for version, chooser := range choosers {
+		major, minor, ok := parseVersion(version)
+		if !ok || chooser == nil {
+			invalid = append(invalid, version)
+			continue
+		}
+		a = append(a, algorithm{version: version, major: major, minor: minor, chooser: chooser})
+	}
+	sort.Slice(a, func(i, j int) bool {
+		if a[i].major != a[j].major {
+			return a[i].major > a[j].major
+		}
+		return a[i].minor > a[j].minor
+	})
+	sort.Strings(invalid)
+	return a, invalid
+}
+
+// versions provides the versions of the algorithms.
+func (a algorithms) versions() []string {
+	var versions []string
+	for _, alg := range a {
+		versions = append(versions, alg.version)
+	}
+	return versions
+}
+
+// highest provides the algorithm with the highest version.
+func (a algorithms) highest() (algorithm, bool) {
+	if len(a) == 0 {
+		return algorithm{}, false
+	}
+	return a[0], true
+}
+
+// negotiated provides the algorithm with the highest version the user agent
+// allows to be run. An algorithm is allowed when its version is advertised,
+// or when it has the same major version and a higher minor version than one
+// that is advertised. Any algorithm is allowed for the '*' and 'guess-small'
+// directives.
+//
+// https://tools.ietf.org/html/rfc2295#section-8.4
+func (a algorithms) negotiated(negotiate header.Negotiate) (algorithm, bool) {
+	if negotiate.Contains(header.NegotiateDirective("*"), header.NegotiateDirectiveGuessSmall) {
+		return a.highest()
+	}
+	for _, alg := range a {
+		for _, d := range negotiate.Directives() {
+			if !d.IsRVSAVersion() {
+				continue
+			}
+			major, minor, ok := parseVersion(d.String())
+			if ok && major == alg.major && minor <= alg.minor {
+				return alg, true
+			}
+		}
+	}
+	return algorithm{}, false
+}
+
+// dimensions provides the dimensions consulted by any of the algorithms.
+func (a algorithms) dimensions() []representation.Dimension {
+	var (
+		dimensions []representation.Dimension
+		seen       = make(map[representation.Dimension]bool)
+	)
+	for _, alg := range a {
+		for _, d := range representation.DimensionsOf(alg.chooser) {
+			if !seen[d] {
+				seen[d] = true
+				dimensions = append(dimensions, d)
+			}
+		}
+	}
+	return dimensions
+}
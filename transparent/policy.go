@@ -0,0 +1,71 @@
+/* Copyright 2020 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package transparent
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/freerware/negotiator/header"
+)
+
+// PolicyDecision represents how the transparent negotiator responds to a
+// particular request.
+type PolicyDecision struct {
+	// Adhoc indicates that an 'adhoc' response is served in place of the list
+	// or choice response, such as for buggy user agents.
+	Adhoc bool
+	// ListOverride is the override directive to mark list responses with, if
+	// any.
+	ListOverride header.OverrideDirective
+	// ChoiceOverride is the override directive to mark choice responses with,
+	// if any.
+	ChoiceOverride header.OverrideDirective
+}
+
+// Policy decides how the transparent negotiator responds to the provided
+// request, such as based on the user agent.
+type Policy func(*http.Request) PolicyDecision
+
+// UserAgentPolicy constructs a policy that decides how to respond based on
+// the User-Agent header of the request.
+func UserAgentPolicy(decide func(userAgent string) PolicyDecision) Policy {
+	return func(r *http.Request) PolicyDecision {
+		return decide(r.UserAgent())
+	}
+}
+
+// policy constructs the default policy from the provided options, which
+// serves adhoc responses to the user agents configured and marks responses
+// with the override directives configured.
+func policy(o Options) Policy {
+	if o.Policy != nil {
+		return o.Policy
+	}
+	return UserAgentPolicy(func(userAgent string) PolicyDecision {
+		d := PolicyDecision{
+			ListOverride:   o.ListOverride,
+			ChoiceOverride: o.ChoiceOverride,
+		}
+		for _, ua := range o.AdhocUserAgents {
+			if strings.Contains(strings.ToLower(userAgent), strings.ToLower(ua)) {
+				d.Adhoc = true
+				break
+			}
+		}
+		return d
+	})
+}
@@ -0,0 +1,129 @@
+/* Copyright 2020 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package transparent
+
+import (
+	"net/url"
+	"path"
+	"sync"
+
+	"github.com/freerware/negotiator/representation"
+)
+
+// Registry tracks the negotiable resources, along with the variants of the
+// resources negotiated transparently, so that variants which are themselves
+// negotiable can be detected. It is safe for concurrent use.
+//
+// https://tools.ietf.org/html/rfc2295#section-8.5
+type Registry struct {
+	mu         sync.RWMutex
+	negotiable map[string]struct{}
+	variants   map[string]struct{}
+}
+
+// NewRegistry constructs an empty registry.
+func NewRegistry() *Registry {
+	return &Registry{
+		negotiable: make(map[string]struct{}),
+		variants:   make(map[string]struct{}),
+	}
+}
+
+// route provides the route of the provided URL, which is its cleaned path.
+func route(u url.URL) string {
+	return path.Clean("/" + u.Path)
+}
+
+// located determines if the provided content location locates a resource,
+// which is not the case when it is empty.
+func located(u url.URL) bool {
+	return len(u.String()) > 0
+}
+
+// Register marks the resources with the provided routes, such as
+// '/papers/1', as negotiable. Empty routes are ignored.
+func (r *Registry) Register(routes ...string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, rt := range routes {
+		if len(rt) > 0 {
+			r.negotiable[path.Clean("/"+rt)] = struct{}{}
+		}
+	}
+}
+
+// RegisterRepresentations marks the resources located by the provided
+// representations as negotiable. Representations without a location are
+// ignored.
+func (r *Registry) RegisterRepresentations(reps ...representation.Representation) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, rep := range reps {
+		if loc := rep.ContentLocation(); located(loc) {
+			r.negotiable[route(loc)] = struct{}{}
+		}
+	}
+}
+
+// RegisterVariants marks the resources located by the provided
+// representations as variants of a resource negotiated transparently, so
+// that requests carrying the Negotiate header that arrive at them are
+// answered with 506 Variant Also Negotiates. Representations without a
+// location are ignored.
+func (r *Registry) RegisterVariants(reps ...representation.Representation) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, rep := range reps {
+		if loc := rep.ContentLocation(); located(loc) {
+			r.variants[route(loc)] = struct{}{}
+		}
+	}
+}
+
+// IsNegotiable determines if the resource with the provided URL is
+// negotiable.
+func (r *Registry) IsNegotiable(u url.URL) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	_, ok := r.negotiable[route(u)]
+	return ok
+}
+
+// IsVariant determines if the resource with the provided URL is a variant of
+// a resource negotiated transparently.
+func (r *Registry) IsVariant(u url.URL) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	_, ok := r.variants[route(u)]
+	return ok
+}
+
+// alsoNegotiates determines if any of the provided variants of the resource
+// with the provided URL is negotiable.
+func (r *Registry) alsoNegotiates(resource url.URL, reps ...representation.Representation) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for _, rep := range reps {
+		loc := rep.ContentLocation()
+		if !located(loc) || route(loc) == route(resource) {
+			continue
+		}
+		if _, ok := r.negotiable[route(loc)]; ok {
+			return true
+		}
+	}
+	return false
+}
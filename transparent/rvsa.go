@@ -16,9 +16,11 @@
 package transparent
 
 import (
+	"math"
 	"net/http"
+	"strings"
 
-	"github.com/freerware/negotiator/internal/header"
+	"github.com/freerware/negotiator/header"
 	"github.com/freerware/negotiator/representation"
 )
 
@@ -33,43 +35,53 @@ func RVSA1() representation.Chooser {
 	return rvsa1{}
 }
 
+// Dimensions provides the dimensions consulted by the algorithm.
+func (c rvsa1) Dimensions() []representation.Dimension {
+	return []representation.Dimension{
+		representation.DimensionMediaType,
+		representation.DimensionLanguage,
+		representation.DimensionCharset,
+		representation.DimensionEncoding,
+		representation.DimensionFeatures,
+	}
+}
+
 // Choose determines the 'best' representation from the provided set.
 func (c rvsa1) Choose(
 	r *http.Request, reps ...representation.Representation,
 ) (representation.Representation, error) {
 	var (
-		a header.Accept
-		// TODO(FREER) support encoding extension.
-		// ae header.AcceptEncoding
+		a   header.Accept
+		ae  header.AcceptEncoding
 		al  header.AcceptLanguage
 		ac  header.AcceptCharset
 		af  header.AcceptFeatures
 		err error
 	)
 
+	cache := header.CacheFrom(r.Context())
 	accept := r.Header["Accept"]
-	if a, err = header.NewAccept(accept); err != nil {
+	if a, err = cache.Accept(accept); err != nil {
 		return nil, err
 	}
 
-	// TODO(FREER) support encoding extension.
-	// acceptEncodingEncoding := r.Header["Accept-Encoding"]
-	// if ae, err = header.NewAcceptEncoding(acceptEncoding); err != nil {
-	//	return nil, err
-	//}
+	acceptEncoding := r.Header["Accept-Encoding"]
+	if ae, err = cache.AcceptEncoding(acceptEncoding); err != nil {
+		return nil, err
+	}
 
 	acceptLanguage := r.Header["Accept-Language"]
-	if al, err = header.NewAcceptLanguage(acceptLanguage); err != nil {
+	if al, err = cache.AcceptLanguage(acceptLanguage); err != nil {
 		return nil, err
 	}
 
 	acceptCharset := r.Header["Accept-Charset"]
-	if ac, err = header.NewAcceptCharset(acceptCharset); err != nil {
+	if ac, err = cache.AcceptCharset(acceptCharset); err != nil {
 		return nil, err
 	}
 
 	acceptFeatures := r.Header["Accept-Features"]
-	if af, err = header.NewAcceptFeatures(acceptFeatures); err != nil {
+	if af, err = cache.AcceptFeatures(acceptFeatures); err != nil {
 		return nil, err
 	}
 
@@ -79,16 +91,21 @@ func (c rvsa1) Choose(
 		qt, twc := c.acceptQuality(rep, a)
 		qc, cwc := c.acceptCharsetQuality(rep, ac)
 		ql, lwc := c.acceptLanguageQuality(rep, al)
-		qf, fwc := c.acceptFeatureQuality(rep, af)
+		qe, ewc := c.acceptEncodingQuality(rep, ae)
+		qf, fwc, err := c.acceptFeatureQuality(rep, af)
+		if err != nil {
+			return nil, err
+		}
 
-		isDefinite := !twc && !cwc && !lwc && !fwc
+		isDefinite := !twc && !cwc && !lwc && !ewc && !fwc
 		variants = append(variants, representation.RankedRepresentation{
 			Representation:        rep,
 			SourceQualityValue:    qs,
 			MediaTypeQualityValue: qt.Float(),
 			CharsetQualityValue:   qc.Float(),
 			LanguageQualityValue:  ql.Float(),
-			FeatureQualityValue:   qf.Float(),
+			EncodingQualityValue:  qe.Float(),
+			FeatureQualityValue:   qf,
 			IsDefinite:            isDefinite,
 		})
 	}
@@ -155,7 +172,7 @@ func (c rvsa1) acceptLanguageQuality(
 		return header.QualityValueMaximum, true
 	}
 	ql := header.QualityValueMinimum
-	for _, lr := range acceptLanguage {
+	for _, lr := range acceptLanguage.Specified() {
 		if lr.Compatible(rep.ContentLanguage()) {
 			ql = lr.QualityValue()
 			usedWildcard = lr.IsWildcard()
@@ -189,36 +206,101 @@ func (c rvsa1) acceptCharsetQuality(
 	return qc, usedWildcard
 }
 
-// acceptFeatureQuality determines the quality score for a
-// represenations language based on the Accept-Feature header.
-func (c rvsa1) acceptFeatureQuality(
+// acceptEncodingQuality determines the quality score for a
+// represenations content codings based on the Accept-Encoding header.
+//
+// A representation without content codings is acceptable unless the identity
+// coding is excluded, either explicitly or by a '*' range, while each of the
+// content codings applied to a representation must be acceptable.
+//
+// https://tools.ietf.org/html/rfc7231#section-5.3.4
+func (c rvsa1) acceptEncodingQuality(
 	rep representation.Representation,
-	acceptFeature header.AcceptFeatures,
+	acceptEncoding header.AcceptEncoding,
 ) (header.QualityValue, bool) {
-	var usedWildcard bool
-	if len(rep.ContentFeatures()) == 0 {
-		return header.QualityValueMaximum, false
+	var codings []string
+	for _, e := range rep.ContentEncoding() {
+		if e = strings.TrimSpace(e); len(e) > 0 && !strings.EqualFold(e, "identity") {
+			codings = append(codings, e)
+		}
+	}
+	quality := func(coding string) (header.QualityValue, bool, bool) {
+		var (
+			qv       header.QualityValue
+			wildcard bool
+		)
+		for _, cr := range acceptEncoding.CodingRanges() {
+			if strings.EqualFold(cr.CodingRange(), coding) {
+				return cr.QualityValue(), false, true
+			}
+			if cr.IsWildcard() && !wildcard {
+				qv, wildcard = cr.QualityValue(), true
+			}
+		}
+		return qv, wildcard, wildcard
 	}
-	if acceptFeature.IsEmpty() {
+	if len(codings) == 0 {
+		qv, wildcard, ok := quality("identity")
+		if !ok || (wildcard && !qv.Equals(header.QualityValueMinimum)) {
+			return header.QualityValueMaximum, false
+		}
+		return qv, false
+	}
+	if acceptEncoding.IsEmpty() {
 		return header.QualityValueMaximum, true
 	}
+	var usedWildcard bool
+	qe := header.QualityValueMaximum
+	for _, coding := range codings {
+		qv, wildcard, ok := quality(coding)
+		if !ok {
+			return header.QualityValueMinimum, false
+		}
+		if qv.LessThan(qe) {
+			qe = qv
+		}
+		usedWildcard = usedWildcard || wildcard
+	}
+	return qe, usedWildcard
+}
+
+// acceptFeatureQuality determines the quality degradation factor for a
+// represenations feature list based on the Accept-Features header, which is
+// not definite when it was guessed. The factor is not a quality value, as
+// true-improvement factors can raise it above 1.
+//
+// https://tools.ietf.org/html/rfc2295#section-6.4
+func (c rvsa1) acceptFeatureQuality(
+	rep representation.Representation,
+	acceptFeatures header.AcceptFeatures,
+) (float32, bool, error) {
+	if len(rep.ContentFeatures()) == 0 {
+		return header.QualityValueMaximum.Float(), false, nil
+	}
 	featureList, err := header.NewFeatureList(rep.ContentFeatures())
 	if err != nil {
-		panic(err) // TODO(FREER)
+		return 0, false, err
 	}
-	degradation := featureList.QualityDegradation(
-		acceptFeature.AsFeatureSets(),
-	)
-	return header.QualityValue(degradation), usedWildcard // TODO(FREER)
+	degradation, guessed := acceptFeatures.QualityDegradation(featureList)
+	return round5(float64(degradation)), guessed, nil
 }
 
+// overallQuality computes the overall quality of the variant.
+//
+// https://tools.ietf.org/html/rfc2296#section-3.5
 func (c rvsa1) overallQuality(v representation.RankedRepresentation) float32 {
-	qs := v.SourceQualityValue
-	qt := v.MediaTypeQualityValue
-	qc := v.CharsetQualityValue
-	ql := v.LanguageQualityValue
-	qf := v.FeatureQualityValue
-	overall := qs * qt * qc * ql * qf
-	qv := header.QualityValue(overall)
-	return qv.Round(5).Float()
+	qs := float64(v.SourceQualityValue)
+	qt := float64(v.MediaTypeQualityValue)
+	qc := float64(v.CharsetQualityValue)
+	ql := float64(v.LanguageQualityValue)
+	qe := float64(v.EncodingQualityValue)
+	qf := float64(v.FeatureQualityValue)
+	return round5(qs * qt * qc * ql * qe * qf)
+}
+
+// round5 rounds the provided value to five decimal places.
+//
+// https://tools.ietf.org/html/rfc2296#section-3.5
+func round5(v float64) float32 {
+	return float32(math.Round(v*1e5) / 1e5)
 }
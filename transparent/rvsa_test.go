@@ -4,6 +4,7 @@ import (
 	"net/http/httptest"
 	"testing"
 
+	"github.com/freerware/negotiator/header"
 	_representation "github.com/freerware/negotiator/internal/representation"
 	"github.com/freerware/negotiator/internal/test"
 	"github.com/freerware/negotiator/representation"
@@ -59,7 +60,7 @@ func (s *RVSATestSuite) TestRVSA_Choose_NewAcceptCharsetErr() {
 	request.Header.Add("Accept", "application/json")
 	request.Header.Add("Accept-Encoding", "gzip")
 	request.Header.Add("Accept-Language", "en-US")
-	request.Header.Add("Accept-Charset", "")
+	request.Header.Add("Accept-Charset", "ascii;q=abc")
 	variants := []representation.Representation{}
 
 	// action.
@@ -311,6 +312,216 @@ func (s *RVSATestSuite) TestRVSA_Choose_ZeroQualityFactor() {
 	s.Require().Nil(chosen)
 }
 
+func (s *RVSATestSuite) TestRVSA_Choose_NewAcceptEncodingErr() {
+	// arrange.
+	request := httptest.NewRequest("GET", "http://freer.ddns.net/thing", nil)
+	request.Header.Add("Accept", "application/json")
+	request.Header.Add("Accept-Encoding", "gzip;q=abc")
+	variants := []representation.Representation{}
+
+	// action.
+	_, err := s.sut.Choose(request, variants...)
+
+	// assert.
+	s.Require().Error(err)
+}
+
+func (s *RVSATestSuite) TestRVSA_Choose_AcceptEncoding() {
+	// arrange.
+	var (
+		html     = "text/html"
+		english  = "en-US"
+		ascii    = "ascii"
+		features = "foo"
+	)
+	variant := func(encodings ...string) representation.Representation {
+		b := _representation.NewBuilder().
+			WithType(html).
+			WithLanguage(english).
+			WithCharset(ascii).
+			WithSourceQuality(1.0).
+			WithFeature(features)
+		for _, e := range encodings {
+			b = b.WithEncoding(e)
+		}
+		return b.Build(test.RepresentationBuilderFunc)
+	}
+	gzip, br, identity := variant("gzip"), variant("br"), variant()
+	tests := []struct {
+		name           string
+		acceptEncoding []string
+		variants       []representation.Representation
+		chosen         representation.Representation
+	}{
+		{"Preferred", []string{"gzip;q=0.5, br"}, []representation.Representation{gzip, br}, br},
+		{"Forbidden", []string{"gzip;q=0"}, []representation.Representation{gzip}, nil},
+		{"ForbiddenWithIdentity", []string{"gzip;q=0"}, []representation.Representation{gzip, identity}, identity},
+		{"Unlisted", []string{"br"}, []representation.Representation{gzip}, nil},
+		{"IdentityPreferred", []string{"gzip;q=0.5, identity"}, []representation.Representation{gzip, identity}, identity},
+		{"IdentityByDefault", []string{"gzip;q=0.5"}, []representation.Representation{gzip, identity}, identity},
+		{"IdentityForbidden", []string{"gzip;q=0.5, identity;q=0"}, []representation.Representation{gzip, identity}, gzip},
+		{"WildcardForbidsIdentity", []string{"gzip;q=0.5, *;q=0"}, []representation.Representation{gzip, identity}, gzip},
+		{"WildcardForbidden", []string{"*;q=0"}, []representation.Representation{br}, nil},
+		{"NotDefinite_Wildcard", []string{"*"}, []representation.Representation{gzip}, nil},
+		{"NotDefinite_MissingAcceptEncoding", nil, []representation.Representation{gzip}, nil},
+		{"MissingAcceptEncoding", nil, []representation.Representation{identity}, identity},
+		{"MultipleCodings", []string{"gzip, br;q=0"}, []representation.Representation{variant("gzip", "br")}, nil},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			// arrange.
+			request := httptest.NewRequest("GET", "http://freer.ddns.net/thing", nil)
+			request.Header.Add("Accept", html)
+			request.Header.Add("Accept-Language", english)
+			request.Header.Add("Accept-Charset", ascii)
+			request.Header.Add("Accept-Features", features)
+			request.Header["Accept-Encoding"] = tt.acceptEncoding
+
+			// action.
+			chosen, err := s.sut.Choose(request, tt.variants...)
+
+			// assert.
+			s.Require().NoError(err)
+			s.Equal(tt.chosen, chosen)
+		})
+	}
+}
+
+func (s *RVSATestSuite) TestRVSA_Choose_InvalidFeatures() {
+	// arrange.
+	request := httptest.NewRequest("GET", "http://freer.ddns.net/thing", nil)
+	request.Header.Add("Accept-Features", "tables")
+	v := _representation.NewBuilder().
+		WithType("text/html").
+		WithSourceQuality(1.0).
+		WithFeature("tables;+abc").
+		Build(test.RepresentationBuilderFunc)
+
+	// action.
+	chosen, err := s.sut.Choose(request, v)
+
+	// assert.
+	s.Require().ErrorIs(err, header.ErrInvalidPredicateListElement)
+	s.Nil(chosen)
+}
+
+func (s *RVSATestSuite) TestRVSA_OverallQuality() {
+	tests := []struct {
+		name    string
+		variant representation.RankedRepresentation
+		out     float32
+	}{
+		{"Rounded", representation.RankedRepresentation{
+			SourceQualityValue:    0.9,
+			MediaTypeQualityValue: 0.333333,
+			CharsetQualityValue:   1.0,
+			LanguageQualityValue:  1.0,
+			EncodingQualityValue:  1.0,
+			FeatureQualityValue:   1.0,
+		}, 0.3},
+		{"TrueImprovement", representation.RankedRepresentation{
+			SourceQualityValue:    0.7,
+			MediaTypeQualityValue: 1.0,
+			CharsetQualityValue:   1.0,
+			LanguageQualityValue:  1.0,
+			EncodingQualityValue:  1.0,
+			FeatureQualityValue:   1.5,
+		}, 1.05},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			// action + assert.
+			s.Equal(tt.out, rvsa1{}.overallQuality(tt.variant))
+		})
+	}
+}
+
 func (s *RVSATestSuite) TearDownTest() {
 	s.sut = nil
 }
+
+// RVSAExamplesTestSuite exercises feature negotiation in the Remote Variant
+// Selection Algorithm 1.0 with the Accept-Features example from RFC 2295.
+//
+// https://tools.ietf.org/html/rfc2295#section-8.2
+type RVSAExamplesTestSuite struct {
+	suite.Suite
+
+	// system under test.
+	sut representation.Chooser
+}
+
+func TestRVSAExamplesTestSuite(t *testing.T) {
+	suite.Run(t, new(RVSAExamplesTestSuite))
+}
+
+func (s *RVSAExamplesTestSuite) SetupTest() {
+	s.sut = RVSA1()
+}
+
+func (s *RVSAExamplesTestSuite) TestRVSA_Choose_Features() {
+	const (
+		partial  = "blex, !blebber, colordepth={5}, !screenwidth, papersize=A4, !textwidth, *"
+		complete = "blex, !blebber, colordepth={5}, !screenwidth, papersize=A4, !textwidth"
+	)
+	variant := func(qs float32, features ...string) representation.Representation {
+		b := _representation.NewBuilder().
+			WithType("text/html").
+			WithSourceQuality(qs)
+		for _, f := range features {
+			b = b.WithFeature(f)
+		}
+		return b.Build(test.RepresentationBuilderFunc)
+	}
+	plain := variant(0.5)
+	tests := []struct {
+		name           string
+		acceptFeatures string
+		featured       representation.Representation
+		chosen         string
+	}{
+		{"Exists", partial, variant(1.0, "blex"), "featured"},
+		{"Absent", partial, variant(1.0, "!blebber"), "featured"},
+		{"Unsupported", partial, variant(1.0, "blebber"), "plain"},
+		{"Equals", partial, variant(1.0, "papersize=A4"), "featured"},
+		{"NotEquals", partial, variant(1.0, "papersize!=A3"), "featured"},
+		{"ExclusiveEquals", partial, variant(1.0, "colordepth=5"), "featured"},
+		{"WithinRange", partial, variant(1.0, "colordepth=[4-8]"), "featured"},
+		{"OutsideRange", partial, variant(1.0, "colordepth=[6-8]"), "plain"},
+		{"AbsentRange", partial, variant(1.0, "screenwidth=[-800]"), "plain"},
+		{"PredicateBag", partial, variant(1.0, "[blebber blex]"), "featured"},
+		{"TrueImprovement", partial, variant(0.4, "blex;+1.5"), "featured"},
+		{"FalseDegradation", partial, variant(1.0, "blebber;-0.4"), "plain"},
+		{"UnknownFeature", partial, variant(1.0, "tables;+1.0-0.9"), "none"},
+		{"UnknownFeature_Degraded", partial, variant(1.0, "tables"), "plain"},
+		{"CompleteFeatureSet_Absent", complete, variant(1.0, "!tables"), "featured"},
+		{"CompleteFeatureSet_Exists", complete, variant(1.0, "tables;+1.0-0.9"), "featured"},
+		{"MissingAcceptFeatures", "", variant(1.0, "blex;+1.0-0.9"), "none"},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			// arrange.
+			request := httptest.NewRequest("GET", "http://freer.ddns.net/thing", nil)
+			request.Header.Add("Accept", "text/html")
+			if len(tt.acceptFeatures) > 0 {
+				request.Header.Add("Accept-Features", tt.acceptFeatures)
+			}
+			chosen := map[string]representation.Representation{
+				"featured": tt.featured,
+				"plain":    plain,
+				"none":     nil,
+			}[tt.chosen]
+
+			// action.
+			rep, err := s.sut.Choose(request, tt.featured, plain)
+
+			// assert.
+			s.Require().NoError(err)
+			s.Equal(chosen, rep)
+		})
+	}
+}
+
+func (s *RVSAExamplesTestSuite) TearDownTest() {
+	s.sut = nil
+}
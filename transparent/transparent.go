@@ -21,11 +21,11 @@ import (
 	"math"
 	"net/http"
 	"net/url"
-	"strconv"
 	"strings"
 
 	"github.com/freerware/negotiator"
-	"github.com/freerware/negotiator/internal/header"
+	"github.com/freerware/negotiator/header"
+	"github.com/freerware/negotiator/proactive"
 	"github.com/freerware/negotiator/representation"
 	"github.com/uber-go/tally"
 	"go.uber.org/zap"
@@ -37,6 +37,9 @@ import (
 //
 // ➣ The algorithm used for serving choice responses is the RVSA 1.0 algorithm.
 //
+// ➣ The algorithm used for serving normal and adhoc responses is the Apache
+// HTTP server proactive content negotiation algorithm.
+//
 // ➣ For guess-small responses, the choice response can be no more than 50
 // bytes larger than the list response.
 //
@@ -58,9 +61,13 @@ var (
 
 // Defines the tags and scope names for transparent negotiator metrics.
 var (
-	scopeTagTransparent              = map[string]string{"negotiator": "transparent"}
-	scopeNameTransparentTimer        = "negotiate"
-	scopeNameTransparentErrorCounter = "negotiate.error"
+	scopeTagTransparent                  = map[string]string{"negotiator": "transparent"}
+	scopeNameTransparentTimer            = "negotiate"
+	scopeNameTransparentErrorCounter     = "negotiate.error"
+	scopeNameTransparentNoContentCounter = "negotiate.no_content"
+
+	scopeNameTransparentVariantAlsoNegotiatesCounter = "negotiate.variant_also_negotiates"
+	scopeNameTransparentRVSACounter                  = "negotiate.rvsa"
 )
 
 var jsonList = func(reps ...representation.Representation) representation.Representation {
@@ -78,8 +85,16 @@ var jsonList = func(reps ...representation.Representation) representation.Repres
 type Negotiator struct {
 	maximumVariantListSize        int
 	listRepresentationConstructor representation.ListConstructor
-	chooser                       representation.Chooser
+	algorithms                    algorithms
+	serverChooser                 representation.Chooser
 	guessSmallThreshold           int
+	headerPolicy                  negotiator.HeaderPolicy
+	headerCache                   *header.Cache
+	tcn                           header.TCN
+	policy                        Policy
+	registry                      *Registry
+	variantAlsoNegotiates         representation.ListConstructor
+	vary                          header.Vary
 	logger                        *zap.Logger
 	scope                         tally.Scope
 }
@@ -92,29 +107,92 @@ func New(options ...Option) Negotiator {
 		MaximumVariantListSize:        10,
 		ListRepresentationConstructor: jsonList,
 		Chooser:                       RVSA1(),
-		GuessSmallThreshold:           50,
-		Logger:                        zap.NewNop(),
-		Scope:                         tally.NoopScope,
+		ServerChooser:                 proactive.ApacheHTTPD(),
+		VariantAlsoNegotiates: representation.VariantAlsoNegotiatesProblem(
+			representation.ProblemJSON,
+		),
+		GuessSmallThreshold: 50,
+		Logger:              zap.NewNop(),
+		Scope:               tally.NoopScope,
 	}
 	// apply options.
 	for _, opt := range options {
 		opt(&o)
 	}
+	choosers := make(map[string]representation.Chooser, len(o.Algorithms)+1)
+	for version, c := range o.Algorithms {
+		choosers[version] = c
+	}
+	if _, ok := choosers[rvsa1Version]; !ok && o.Chooser != nil {
+		choosers[rvsa1Version] = o.Chooser
+	}
+	algs, invalid := newAlgorithms(choosers)
+	o.Algorithms = choosers
 	n := Negotiator{
 		maximumVariantListSize:        o.MaximumVariantListSize,
 		listRepresentationConstructor: o.ListRepresentationConstructor,
-		chooser:                       o.Chooser,
+		algorithms:                    algs,
+		serverChooser:                 o.ServerChooser,
 		guessSmallThreshold:           o.GuessSmallThreshold,
+		headerPolicy:                  o.HeaderPolicy,
+		tcn:                           header.EmptyTCN.Add(o.TCNExtensions...),
+		policy:                        policy(o),
+		registry:                      o.Registry,
+		variantAlsoNegotiates:         o.VariantAlsoNegotiates,
+		vary:                          vary(o),
 		logger:                        o.Logger,
 		scope:                         o.Scope.Tagged(scopeTagTransparent),
 	}
+	if o.HeaderCacheSize > 0 {
+		n.headerCache = header.NewCache(o.HeaderCacheSize, n.scope)
+	}
+	if len(invalid) > 0 {
+		n.logger.Warn("ignored remote variant selection algorithms with invalid versions",
+			zap.Strings("rvsa-versions", invalid))
+	}
 	n.logger.Debug("negotiator configuration",
 		zap.String("type", "transparent"),
+		zap.Strings("rvsa-versions", algs.versions()),
 		zap.Int("maximum-variant-list-size", n.maximumVariantListSize),
-		zap.Int("guess-small-threshold", n.guessSmallThreshold))
+		zap.Int("guess-small-threshold", n.guessSmallThreshold),
+		zap.Stringer("header-policy", n.headerPolicy),
+		zap.Int("header-cache-size", o.HeaderCacheSize),
+		zap.String("tcn-extensions", n.tcn.ValuesAsString()),
+		zap.Strings("adhoc-user-agents", o.AdhocUserAgents),
+		zap.Stringer("list-override", o.ListOverride),
+		zap.Stringer("choice-override", o.ChoiceOverride),
+		zap.String("vary", n.vary.ValuesAsString()))
 	return n
 }
 
+// sanitize disregards the malformed request headers according to the header
+// policy of the negotiator.
+func (n Negotiator) sanitize(r *http.Request) *http.Request {
+	r, ignored := header.Sanitize(r, n.headerPolicy)
+	for _, err := range ignored {
+		n.logger.Info("ignored invalid header", zap.Error(err))
+	}
+	return r
+}
+
+// vary determines the request headers that influence the choice responses
+// produced by a negotiator configured with the provided options.
+//
+// https://tools.ietf.org/html/rfc2295#section-10.2
+func vary(o Options) header.Vary {
+	v := header.EmptyVary.Add(representation.DimensionNegotiate.String())
+	algs, _ := newAlgorithms(o.Algorithms)
+	for _, d := range algs.dimensions() {
+		v = v.Add(d.String())
+	}
+	if o.ServerChooser != nil {
+		for _, d := range representation.DimensionsOf(o.ServerChooser) {
+			v = v.Add(d.String())
+		}
+	}
+	return v
+}
+
 // Negotiate performs transparent content negotiation with the representations
 // provided.
 func (n Negotiator) Negotiate(
@@ -127,54 +205,129 @@ func (n Negotiator) Negotiate(
 		}
 	}()
 
+	var d negotiator.Decision
+	if d, err = n.decide(ctx.Request, reps...); err != nil {
+		return err
+	}
+	_, err = d.WriteTo(ctx.ResponseWriter)
+	return err
+}
+
+// Decide performs transparent content negotiation with the representations
+// provided, returning the decision made without responding to the user
+// agent.
+func (n Negotiator) Decide(
+	r *http.Request, reps ...representation.Representation,
+) (d negotiator.Decision, err error) {
+	defer n.scope.Timer(scopeNameTransparentTimer).Start().Stop()
+	defer func() {
+		if err != nil {
+			n.scope.Counter(scopeNameTransparentErrorCounter).Inc(1)
+		}
+	}()
+	return n.decide(r, reps...)
+}
+
+// decide determines the outcome of transparent content negotiation with the
+// representations provided.
+func (n Negotiator) decide(
+	r *http.Request, reps ...representation.Representation,
+) (d negotiator.Decision, err error) {
+	if len(reps) == 0 {
+		status := http.StatusNoContent
+		n.logger.Info("no representations to negotiate", zap.Int("status", status))
+		n.scope.Counter(scopeNameTransparentNoContentCounter).Inc(1)
+		d = negotiator.Decision{
+			Outcome:    negotiator.OutcomeNoContent,
+			StatusCode: status,
+			Header:     make(http.Header),
+		}
+		return d, err
+	}
 	if len(reps) > n.maximumVariantListSize {
-		return ErrVariantListSizeExceeded
+		return d, ErrVariantListSizeExceeded
 	}
 
+	r = n.sanitize(r)
+	if n.headerCache != nil {
+		// share the parsed headers with the algorithm.
+		r = r.WithContext(header.WithCache(r.Context(), n.headerCache))
+	}
 	var negotiate header.Negotiate
-	if negotiate, err = header.NewNegotiate(ctx.Request.Header["Negotiate"]); err != nil {
-		return err
+	if negotiate, err = n.headerCache.Negotiate(r.Header["Negotiate"]); err != nil {
+		return d, err
 	}
 
-	// determine when the user agent wants the server to choose the best
-	// variant on it's behalf.
-	shouldChoose := negotiate.Contains(header.NegotiateDirective("*")) ||
-		negotiate.ContainsRVSA("1.0") ||
-		negotiate.Contains(header.NegotiateDirectiveGuessSmall)
+	// variants that are themselves negotiable would create negotiation loops.
+	//
+	// https://tools.ietf.org/html/rfc2295#section-8.5
+	if n.registry != nil {
+		if negotiate.SupportsTCN() && n.registry.IsVariant(*r.URL) {
+			n.logger.Debug("request carrying the negotiate header arrived at a variant resource",
+				zap.String("resource-url", r.URL.String()))
+			return n.variantAlsoNegotiatesResponse(reps...)
+		}
+		if n.registry.alsoNegotiates(*r.URL, reps...) {
+			n.logger.Debug("variant resource is a negotiable resource",
+				zap.String("resource-url", r.URL.String()))
+			return n.variantAlsoNegotiatesResponse(reps...)
+		}
+	}
+
+	p := n.policy(r)
+	if p.Adhoc {
+		return n.adhocResponse(r, reps...)
+	}
 
+	// user agents that do not support transparent content negotiation are
+	// served a normal response.
+	//
+	// https://tools.ietf.org/html/rfc2295#section-8.4
+	if !negotiate.SupportsTCN() {
+		return n.normalResponse(r, reps...)
+	}
+
+	// determine when the user agent wants the server to choose the best
+	// variant on it's behalf, along with the algorithm to do so.
+	alg, shouldChoose := n.algorithms.negotiated(negotiate)
 	if !shouldChoose {
-		return n.listResponse(ctx, reps...)
+		n.logger.Debug("none of the remote variant selection algorithms are allowed",
+			zap.String("negotiate", negotiate.ValuesAsString()),
+			zap.Strings("rvsa-versions", n.algorithms.versions()))
+		return n.listResponse(p, reps...)
 	}
 
 	var rep representation.Representation
-	if rep, err = n.chooser.Choose(ctx.Request, reps...); err != nil {
-		return err
+	if rep, err = n.choose(alg, r, reps...); err != nil {
+		return d, err
 	}
 
 	if rep == nil {
-		return n.listResponse(ctx, reps...)
+		return n.listResponse(p, reps...)
 	}
 
 	if negotiate.Contains(header.NegotiateDirectiveGuessSmall) {
+		// estimate the size of each response, which avoids buffering
+		// representations capable of being streamed.
 		list := n.listRepresentationConstructor(reps...)
-		var listBytes []byte
-		if listBytes, err = list.Bytes(); err != nil {
-			return err
+		var listLength int
+		if listLength, err = representation.ContentLength(list); err != nil {
+			return d, err
 		}
 
-		var choiceBytes []byte
-		if choiceBytes, err = rep.Bytes(); err != nil {
-			return err
+		var choiceLength int
+		if choiceLength, err = representation.ContentLength(rep); err != nil {
+			return d, err
 		}
 
-		less := len(choiceBytes) < len(listBytes)
-		diff := math.Abs(float64(len(listBytes) - len(choiceBytes)))
+		less := choiceLength < listLength
+		diff := math.Abs(float64(listLength - choiceLength))
 		if !less && diff > float64(n.guessSmallThreshold) {
 			n.logger.Debug("choice response is not smaller or not much larger than the list response",
-				zap.Int("choice-response-size", len(choiceBytes)),
-				zap.Int("list-response-size", len(listBytes)),
+				zap.Int("choice-response-size", choiceLength),
+				zap.Int("list-response-size", listLength),
 				zap.Int("guess-small-threshold", n.guessSmallThreshold))
-			return n.listResponse(ctx, reps...)
+			return n.listResponse(p, reps...)
 		}
 	}
 
@@ -228,133 +381,324 @@ func (n Negotiator) Negotiate(
 	}
 
 	loc := rep.ContentLocation()
-	if !isNeighbor(loc, *ctx.Request.URL) {
+	if !isNeighbor(loc, *r.URL) {
 		n.logger.Debug("variant resource is not a neighbor of the negotiable resource",
-			zap.String("resource-url", ctx.Request.URL.String()),
+			zap.String("resource-url", r.URL.String()),
 			zap.String("neighbor-url", loc.String()))
-		return n.listResponse(ctx, reps...)
+		return n.listResponse(p, reps...)
+	}
+	return n.choiceResponse(negotiate, p, alg, reps, rep)
+}
+
+// choose determines the 'best' representation from the provided set with the
+// provided algorithm.
+func (n Negotiator) choose(
+	alg algorithm, r *http.Request, reps ...representation.Representation,
+) (representation.Representation, error) {
+	n.logger.Debug("running remote variant selection algorithm",
+		zap.String("rvsa", alg.version))
+	n.scope.Tagged(map[string]string{"rvsa": alg.version}).
+		Counter(scopeNameTransparentRVSACounter).Inc(1)
+	return alg.chooser.Choose(r, reps...)
+}
+
+// serverChoice determines the 'best' representation from the provided set
+// with the server chooser, which is used when the server chooses on its own.
+// Unlike remote variant selection algorithms, the server chooser is not
+// required to make a definite choice, such that no representation is chosen
+// only when none of them are acceptable.
+func (n Negotiator) serverChoice(
+	r *http.Request, reps ...representation.Representation,
+) (representation.Representation, error) {
+	if n.serverChooser == nil {
+		return nil, nil
+	}
+	n.logger.Debug("running server chooser")
+	return n.serverChooser.Choose(r, reps...)
+}
+
+// entityTag provides a strong entity tag with the provided opaque value.
+func entityTag(opaque string) string {
+	return `"` + opaque + `"`
+}
+
+// variantEntityTag provides the opaque value of the strong entity tag of the
+// provided variant, along with an indication of whether it has one. Only the
+// entity tag reported by variants implementing representation.EntityTagger is
+// utilized, such that variants are never serialized to derive one, and
+// variants reporting a weak entity tag have no strong entity tag.
+func variantEntityTag(rep representation.Representation) (string, bool) {
+	if r, ok := rep.(representation.RankedRepresentation); ok {
+		rep = r.Representation
 	}
-	return n.choiceResponse(ctx, reps, rep)
+	t, ok := rep.(representation.EntityTagger)
+	if !ok {
+		return "", false
+	}
+	tag, known := t.EntityTag()
+	tag = strings.TrimSpace(tag)
+	if !known || strings.HasPrefix(tag, "W/") || len(tag) < 2 ||
+		!strings.HasPrefix(tag, `"`) || !strings.HasSuffix(tag, `"`) {
+		return "", false
+	}
+	return tag[1 : len(tag)-1], true
 }
 
-// listResponse is responsible for responding to the user agent with a 'list'
-// response, including the representation describing the available
+// normalResponse is responsible for deciding to respond to a user agent that
+// does not support transparent content negotiation with a normal response,
+// which includes the representation chosen by the algorithm when there is one
+// and the representation describing the available representations otherwise.
+func (n Negotiator) normalResponse(
+	r *http.Request, reps ...representation.Representation,
+) (d negotiator.Decision, err error) {
+	var rep representation.Representation
+	if rep, err = n.serverChoice(r, reps...); err != nil {
+		return d, err
+	}
+
+	if rep == nil {
+		list := n.listRepresentationConstructor(reps...)
+		var (
+			h      = negotiator.ContentHeaders(list)
+			status = http.StatusMultipleChoices
+		)
+		h.Set("Vary", n.vary.ValuesAsString())
+		n.logger.Info("multiple choices",
+			zap.String("content-type", h.Get("Content-Type")),
+			zap.String("content-encoding", h.Get("Content-Encoding")),
+			zap.String("content-language", h.Get("Content-Language")),
+			zap.String("content-charset", h.Get("Content-Charset")),
+			zap.Int("status", status))
+		d = negotiator.Decision{
+			Outcome:    negotiator.OutcomeMultipleChoices,
+			List:       list,
+			StatusCode: status,
+			Header:     h,
+		}
+		return d, nil
+	}
+
+	var (
+		h      = negotiator.ContentHeaders(rep)
+		loc    = rep.ContentLocation()
+		status = http.StatusOK
+	)
+	h.Set("Vary", n.vary.ValuesAsString())
+	h.Set("Content-Location", (&loc).String())
+	n.logger.Info("normal response",
+		zap.String("content-type", h.Get("Content-Type")),
+		zap.String("content-encoding", h.Get("Content-Encoding")),
+		zap.String("content-language", h.Get("Content-Language")),
+		zap.String("content-charset", h.Get("Content-Charset")),
+		zap.Int("status", status),
+		zap.String("content-location", h.Get("Content-Location")))
+	d = negotiator.Decision{
+		Outcome:        negotiator.OutcomeAcceptable,
+		Representation: rep,
+		StatusCode:     status,
+		Header:         h,
+	}
+	return d, nil
+}
+
+// variantAlsoNegotiatesResponse is responsible for deciding to respond to the
+// user agent with a 506 HTTP status code, along with a representation
+// describing the variants of the resource.
+func (n Negotiator) variantAlsoNegotiatesResponse(
+	reps ...representation.Representation,
+) (d negotiator.Decision, err error) {
+	var (
+		rep    = n.variantAlsoNegotiates(reps...)
+		h      = negotiator.ContentHeaders(rep)
+		status = http.StatusVariantAlsoNegotiates
+	)
+	n.logger.Info("variant also negotiates",
+		zap.String("content-type", h.Get("Content-Type")),
+		zap.String("content-encoding", h.Get("Content-Encoding")),
+		zap.String("content-language", h.Get("Content-Language")),
+		zap.String("content-charset", h.Get("Content-Charset")),
+		zap.Int("status", status))
+	n.scope.Counter(scopeNameTransparentVariantAlsoNegotiatesCounter).Inc(1)
+	d = negotiator.Decision{
+		Outcome:    negotiator.OutcomeVariantAlsoNegotiates,
+		List:       rep,
+		StatusCode: status,
+		Header:     h,
+	}
+	return d, nil
+}
+
+// adhocResponse is responsible for deciding to respond to the user agent with
+// an 'adhoc' response, which includes the representation chosen by the
+// algorithm when there is one and the representation describing the
+// available representations otherwise.
+//
+// https://tools.ietf.org/html/rfc2295#section-8.5
+func (n Negotiator) adhocResponse(
+	r *http.Request, reps ...representation.Representation,
+) (d negotiator.Decision, err error) {
+	var rep representation.Representation
+	if rep, err = n.serverChoice(r, reps...); err != nil {
+		return d, err
+	}
+	a, err := header.NewAlternates(nil, reps...)
+	if err != nil {
+		return d, err
+	}
+	t := n.tcn.Add(header.TCNValue(header.ResponseTypeAdhoc))
+
+	var (
+		h      http.Header
+		status int
+		list   = n.listRepresentationConstructor(reps...)
+	)
+	if rep != nil {
+		loc := rep.ContentLocation()
+		h, status = negotiator.ContentHeaders(rep), http.StatusOK
+		h.Set("Content-Location", (&loc).String())
+	} else {
+		h, status = negotiator.ContentHeaders(list), http.StatusMultipleChoices
+	}
+	h.Set("Vary", n.vary.ValuesAsString())
+	h.Set("Alternates", a.ValuesAsString())
+	h.Set("TCN", t.ValuesAsString())
+	n.logger.Info("adhoc response",
+		zap.String("content-type", h.Get("Content-Type")),
+		zap.String("content-encoding", h.Get("Content-Encoding")),
+		zap.String("content-language", h.Get("Content-Language")),
+		zap.String("content-charset", h.Get("Content-Charset")),
+		zap.Int("status", status),
+		zap.String("alternates", h.Get("Alternates")),
+		zap.String("tcn", h.Get("TCN")),
+		zap.String("content-location", h.Get("Content-Location")))
+	d = negotiator.Decision{
+		Outcome:        negotiator.OutcomeAdhoc,
+		Representation: rep,
+		List:           list,
+		StatusCode:     status,
+		Header:         h,
+	}
+	return d, nil
+}
+
+// listResponse is responsible for deciding to respond to the user agent with
+// a 'list' response, including the representation describing the available
 // representations and their metadata.
 func (n Negotiator) listResponse(
-	ctx negotiator.NegotiationContext, reps ...representation.Representation,
-) error {
+	p PolicyDecision, reps ...representation.Representation,
+) (d negotiator.Decision, err error) {
 	a, err := header.NewAlternates(reps[0], reps...)
 	if err != nil {
-		return err
+		return d, err
 	}
-	t, err := header.NewTCN([]string{header.ResponseTypeList.String()})
-	if err != nil {
-		return err
+	t := n.tcn.Add(header.TCNValue(header.ResponseTypeList))
+	if len(p.ListOverride) > 0 {
+		t = t.Add(header.TCNValue(p.ListOverride))
 	}
 
 	// construct representation.
 	list := n.listRepresentationConstructor(reps...)
 
-	// serialize.
-	b, err := list.Bytes()
-	if err != nil {
-		return err
-	}
-
-	// respond.
 	var (
-		clen       = len(b)
-		ct         = list.ContentType()
-		ce         = strings.Join(list.ContentEncoding(), ",")
-		clang      = list.ContentLanguage()
-		cc         = list.ContentCharset()
-		alternates = a.ValuesAsString()
-		tcn        = t.ValuesAsString()
-		status     = http.StatusMultipleChoices
+		h      = negotiator.ContentHeaders(list)
+		status = http.StatusMultipleChoices
 	)
-	ctx.ResponseWriter.Header().Add("Alternates", alternates)
-	ctx.ResponseWriter.Header().Add("TCN", tcn)
-	ctx.ResponseWriter.Header().Add("Content-Length", strconv.Itoa(clen))
-	ctx.ResponseWriter.Header().Add("Content-Type", ct)
-	ctx.ResponseWriter.Header().Add("Content-Encoding", ce)
-	ctx.ResponseWriter.Header().Add("Content-Language", clang)
-	ctx.ResponseWriter.Header().Add("Content-Charset", cc)
-	ctx.ResponseWriter.WriteHeader(status)
-	if _, err = ctx.ResponseWriter.Write(b); err == nil {
-		n.logger.Info("list response",
-			zap.Int("content-length", clen),
-			zap.String("content-type", ct),
-			zap.String("content-encoding", ce),
-			zap.String("content-language", clang),
-			zap.String("content-charset", cc),
-			zap.Int("status", status),
-			zap.String("alternates", alternates),
-			zap.String("tcn", tcn))
+	// list responses only vary on the transparent negotiation capabilities
+	// of the user agent, as the variant list is the same for all requests.
+	h.Set("Vary", representation.DimensionNegotiate.String())
+	h.Set("Alternates", a.ValuesAsString())
+	h.Set("TCN", t.ValuesAsString())
+	// the list response is derived solely from the variant list.
+	//
+	// https://tools.ietf.org/html/rfc2295#section-9.1
+	h.Set("ETag", entityTag(a.VariantListValidator()))
+	n.logger.Info("list response",
+		zap.String("content-type", h.Get("Content-Type")),
+		zap.String("content-encoding", h.Get("Content-Encoding")),
+		zap.String("content-language", h.Get("Content-Language")),
+		zap.String("content-charset", h.Get("Content-Charset")),
+		zap.Int("status", status),
+		zap.String("alternates", h.Get("Alternates")),
+		zap.String("tcn", h.Get("TCN")),
+		zap.String("etag", h.Get("ETag")))
+	d = negotiator.Decision{
+		Outcome:    negotiator.OutcomeList,
+		List:       list,
+		StatusCode: status,
+		Header:     h,
 	}
-	return err
+	return d, nil
 }
 
-// choiceResponse is responsible for responding to the user agent with a
-// 'choice' response, including the representation chosen by the remote
-// variant selection algorithm.
+// choiceResponse is responsible for deciding to respond to the user agent
+// with a 'choice' response, including the representation chosen by the
+// remote variant selection algorithm.
 func (n Negotiator) choiceResponse(
-	ctx negotiator.NegotiationContext,
+	negotiate header.Negotiate,
+	p PolicyDecision,
+	alg algorithm,
 	reps []representation.Representation,
 	rep representation.Representation,
-) error {
+) (d negotiator.Decision, err error) {
+	// user agents asked to re-choose need the variant list to do so.
+	withAlternates := negotiate.Contains(header.NegotiateDirectiveTrans, header.NegotiateDirectiveVList) ||
+		p.ChoiceOverride == header.OverrideDirectiveReChoose
+
+	// the structured entity tag of the chosen variant allows caches to
+	// validate both the variant and the variant list, which is only possible
+	// when the variant has a strong entity tag.
+	//
+	// https://tools.ietf.org/html/rfc2295#section-9.2
+	tag, hasStrong := variantEntityTag(rep)
+
 	// If a response from a transparently negotiable resource includes an
 	// Alternates header, this header MUST contain the complete variant list
 	// bound to the negotiable resource. Responses from resources which do not
 	// support transparent content negotiation MAY also use Alternates headers.
 	//
 	// https://tools.ietf.org/html/rfc2295#section-8.3
-	a, err := header.NewAlternates(nil, reps...)
-	if err != nil {
-		return err
-	}
-	t, err := header.NewTCN([]string{header.ResponseTypeChoice.String()})
-	if err != nil {
-		return err
+	var a header.Alternates
+	if withAlternates || hasStrong {
+		if a, err = header.NewAlternates(nil, reps...); err != nil {
+			return d, err
+		}
 	}
-
-	// serialize.
-	b, err := rep.Bytes()
-	if err != nil {
-		return err
+	t := n.tcn.Add(header.TCNValue(header.ResponseTypeChoice))
+	if len(p.ChoiceOverride) > 0 {
+		t = t.Add(header.TCNValue(p.ChoiceOverride))
 	}
 
-	// respond.
 	var (
-		clen       = len(b)
-		ct         = rep.ContentType()
-		ce         = strings.Join(rep.ContentEncoding(), ",")
-		clang      = rep.ContentLanguage()
-		cc         = rep.ContentCharset()
-		alternates = a.ValuesAsString()
-		tcn        = t.ValuesAsString()
-		loc        = rep.ContentLocation()
-		status     = http.StatusOK
+		h      = negotiator.ContentHeaders(rep)
+		loc    = rep.ContentLocation()
+		status = http.StatusOK
 	)
-	ctx.ResponseWriter.Header().Add("Alternates", alternates)
-	ctx.ResponseWriter.Header().Add("TCN", tcn)
-	ctx.ResponseWriter.Header().Add("Content-Location", (&loc).String())
-	ctx.ResponseWriter.Header().Add("Content-Length", strconv.Itoa(clen))
-	ctx.ResponseWriter.Header().Add("Content-Type", ct)
-	ctx.ResponseWriter.Header().Add("Content-Encoding", ce)
-	ctx.ResponseWriter.Header().Add("Content-Language", clang)
-	ctx.ResponseWriter.Header().Add("Content-Charset", cc)
-	ctx.ResponseWriter.WriteHeader(status)
-	if _, err = ctx.ResponseWriter.Write(b); err == nil {
-		n.logger.Info("choice response",
-			zap.Int("content-length", clen),
-			zap.String("content-type", ct),
-			zap.String("content-encoding", ce),
-			zap.String("content-language", clang),
-			zap.String("content-charset", cc),
-			zap.Int("status", status),
-			zap.String("alternates", alternates),
-			zap.String("tcn", tcn),
-			zap.String("content-location", (&loc).String()))
+	h.Set("Vary", n.vary.ValuesAsString())
+	if withAlternates {
+		h.Set("Alternates", a.ValuesAsString())
 	}
-	return err
+	h.Set("TCN", t.ValuesAsString())
+	h.Set("Content-Location", (&loc).String())
+	if hasStrong {
+		h.Set("ETag", entityTag(tag+";"+a.VariantListValidator()))
+	}
+	n.logger.Info("choice response",
+		zap.String("content-type", h.Get("Content-Type")),
+		zap.String("content-encoding", h.Get("Content-Encoding")),
+		zap.String("content-language", h.Get("Content-Language")),
+		zap.String("content-charset", h.Get("Content-Charset")),
+		zap.Int("status", status),
+		zap.String("alternates", h.Get("Alternates")),
+		zap.String("tcn", h.Get("TCN")),
+		zap.String("etag", h.Get("ETag")),
+		zap.String("content-location", h.Get("Content-Location")),
+		zap.String("rvsa", alg.version))
+	d = negotiator.Decision{
+		Outcome:        negotiator.OutcomeChoice,
+		Representation: rep,
+		List:           n.listRepresentationConstructor(reps...),
+		StatusCode:     status,
+		Header:         h,
+	}
+	return d, nil
 }
@@ -16,6 +16,8 @@
 package transparent
 
 import (
+	"github.com/freerware/negotiator"
+	"github.com/freerware/negotiator/header"
 	"github.com/freerware/negotiator/representation"
 	"github.com/uber-go/tally"
 	"go.uber.org/zap"
@@ -26,10 +28,21 @@ import (
 type Options struct {
 	MaximumVariantListSize        int
 	Chooser                       representation.Chooser
+	ServerChooser                 representation.Chooser
+	Algorithms                    map[string]representation.Chooser
 	ListRepresentationConstructor representation.ListConstructor
 	Logger                        *zap.Logger
 	Scope                         tally.Scope
 	GuessSmallThreshold           int
+	HeaderPolicy                  negotiator.HeaderPolicy
+	HeaderCacheSize               int
+	TCNExtensions                 []header.TCNValue
+	AdhocUserAgents               []string
+	ListOverride                  header.OverrideDirective
+	ChoiceOverride                header.OverrideDirective
+	Policy                        Policy
+	Registry                      *Registry
+	VariantAlsoNegotiates         representation.ListConstructor
 }
 
 // Option represents a configurable option for transparent
@@ -52,10 +65,39 @@ var (
 	}
 
 	// RVSA defines the remove variant selection algorithm (RVSA) to leverage
-	// for transparent negotiation.
+	// for transparent negotiation, which is registered as version 1.0.
 	RVSA = func(c representation.Chooser) Option {
+		return RVSAVersion(rvsa1Version, c)
+	}
+
+	// RVSAVersion registers the remote variant selection algorithm (RVSA)
+	// with the provided version, such as '1.0' or '2.1'. The algorithm with
+	// the highest version the user agent advertises within the Negotiate
+	// header is leveraged.
+	//
+	// https://tools.ietf.org/html/rfc2295#section-8.4
+	RVSAVersion = func(version string, c representation.Chooser) Option {
+		return func(o *Options) {
+			algorithms := make(map[string]representation.Chooser, len(o.Algorithms)+1)
+			for v, alg := range o.Algorithms {
+				algorithms[v] = alg
+			}
+			algorithms[version] = c
+			o.Algorithms = algorithms
+			if version == rvsa1Version {
+				o.Chooser = c
+			}
+		}
+	}
+
+	// ServerChooser defines the algorithm to leverage when the server chooses
+	// on its own, such as for normal and adhoc responses. Unlike remote
+	// variant selection algorithms, it need not make a definite choice. A nil
+	// chooser results in a 300 Multiple Choices response whenever the server
+	// chooses on its own.
+	ServerChooser = func(c representation.Chooser) Option {
 		return func(o *Options) {
-			o.Chooser = c
+			o.ServerChooser = c
 		}
 	}
 
@@ -75,6 +117,96 @@ var (
 		}
 	}
 
+	// InvalidHeaders defines how malformed request headers are treated, which
+	// are rejected by default.
+	InvalidHeaders = func(policy negotiator.HeaderPolicy) Option {
+		return func(o *Options) {
+			o.HeaderPolicy = policy
+		}
+	}
+
+	// HeaderCache activates caching of parsed request headers, retaining at
+	// most the provided number of distinct header values so that identical
+	// headers sent across requests are parsed only once.
+	HeaderCache = func(size int) Option {
+		return func(o *Options) {
+			o.HeaderCacheSize = size
+		}
+	}
+
+	// TCNExtensions specifies the extension values to include within the TCN
+	// header of every response.
+	//
+	// https://tools.ietf.org/html/rfc2295#section-8.5
+	TCNExtensions = func(extensions ...header.TCNValue) Option {
+		return func(o *Options) {
+			o.TCNExtensions = append(o.TCNExtensions, extensions...)
+		}
+	}
+
+	// AdhocUserAgents specifies the user agents to serve 'adhoc' responses to,
+	// such as those known to mishandle list and choice responses. A user
+	// agent matches when its User-Agent header contains any of the provided
+	// values, ignoring case.
+	//
+	// https://tools.ietf.org/html/rfc2295#section-8.5
+	AdhocUserAgents = func(userAgents ...string) Option {
+		return func(o *Options) {
+			o.AdhocUserAgents = append(o.AdhocUserAgents, userAgents...)
+		}
+	}
+
+	// ListOverride specifies the override directive to mark list responses
+	// with, such as 're-choose' or 'keep'.
+	//
+	// https://tools.ietf.org/html/rfc2295#section-8.5
+	ListOverride = func(d header.OverrideDirective) Option {
+		return func(o *Options) {
+			o.ListOverride = d
+		}
+	}
+
+	// ChoiceOverride specifies the override directive to mark choice
+	// responses with, such as 're-choose' or 'keep'.
+	//
+	// https://tools.ietf.org/html/rfc2295#section-8.5
+	ChoiceOverride = func(d header.OverrideDirective) Option {
+		return func(o *Options) {
+			o.ChoiceOverride = d
+		}
+	}
+
+	// ResponsePolicy specifies the policy deciding per request whether to
+	// serve an 'adhoc' response and which override directives to mark list
+	// and choice responses with, which takes precedence over the
+	// AdhocUserAgents, ListOverride, and ChoiceOverride options.
+	ResponsePolicy = func(p Policy) Option {
+		return func(o *Options) {
+			o.Policy = p
+		}
+	}
+
+	// NegotiableResources specifies the registry of negotiable resources,
+	// which is consulted to detect variants that are themselves negotiable.
+	// Such variants are answered with a 506 Variant Also Negotiates
+	// response, as are requests carrying the Negotiate header that arrive at
+	// a variant of a resource negotiated transparently.
+	//
+	// https://tools.ietf.org/html/rfc2295#section-8.5
+	NegotiableResources = func(r *Registry) Option {
+		return func(o *Options) {
+			o.Registry = r
+		}
+	}
+
+	// VariantAlsoNegotiatesRepresentation defines the representation to
+	// utilize when returning 506 Variant Also Negotiates responses.
+	VariantAlsoNegotiatesRepresentation = func(c representation.ListConstructor) Option {
+		return func(o *Options) {
+			o.VariantAlsoNegotiates = c
+		}
+	}
+
 	// Logger specifies the logger for the reactive negotiator.
 	Logger = func(l *zap.Logger) Option {
 		return func(o *Options) {
@@ -2,11 +2,14 @@ package transparent_test
 
 import (
 	"errors"
+	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"strings"
 	"testing"
 
 	"github.com/freerware/negotiator"
-	"github.com/freerware/negotiator/internal/header"
+	"github.com/freerware/negotiator/header"
 	_representation "github.com/freerware/negotiator/internal/representation"
 	"github.com/freerware/negotiator/internal/test"
 	"github.com/freerware/negotiator/internal/test/mock"
@@ -48,6 +51,7 @@ func (s *TransparentTestSuite) SetupTest() {
 	s.chooser = mock.NewChooser(s.mc)
 	s.sut = transparent.New(
 		transparent.RVSA(s.chooser),
+		transparent.ServerChooser(s.chooser),
 		transparent.MaximumVariantListSize(3),
 		transparent.Scope(tally.NoopScope),
 		transparent.Logger(zap.NewNop()),
@@ -84,7 +88,7 @@ func (s TransparentTestSuite) TestTransparent_WildcardNegotiateHeader() {
 	s.Equal(v.ContentCharset(), response.Header.Get("Content-Charset"))
 	loc := v.ContentLocation()
 	s.Equal(loc.String(), response.Header.Get("Content-Location"))
-	s.NotEmpty(response.Header.Get("Alternates"))
+	s.Empty(response.Header.Get("Alternates"))
 	s.Equal(header.ResponseTypeChoice.String(), response.Header.Get("TCN"))
 }
 
@@ -112,7 +116,7 @@ func (s TransparentTestSuite) TestTransparent_GuessSmallNegotiateHeader() {
 	// assert.
 	s.Require().NoError(err)
 	response := responseWriter.Result()
-	s.NotEmpty(response.Header.Get("Alternates"))
+	s.Empty(response.Header.Get("Alternates"))
 	s.Equal(header.ResponseTypeChoice.String(), response.Header.Get("TCN"))
 }
 
@@ -145,8 +149,12 @@ func (s TransparentTestSuite) TestTransparent_RSVA1NegotiateHeader() {
 	s.Equal(v.ContentCharset(), response.Header.Get("Content-Charset"))
 	loc := v.ContentLocation()
 	s.Equal(loc.String(), response.Header.Get("Content-Location"))
-	s.NotEmpty(response.Header.Get("Alternates"))
+	s.Empty(response.Header.Get("Alternates"))
 	s.Equal("choice", response.Header.Get("TCN"))
+	s.Equal(
+		"Negotiate, Accept, Accept-Language, Accept-Charset, Accept-Encoding",
+		response.Header.Get("Vary"),
+	)
 }
 
 func (s TransparentTestSuite) TestTransparent_UnrecognizedRSVANegotiateHeader() {
@@ -235,7 +243,7 @@ func (s TransparentTestSuite) TestTransparent_ChoiceResponse() {
 	_json, english, ascii, gzip := "application/json", "en-US", "ascii", "gzip"
 	request := httptest.NewRequest("GET", "http://freer.ddns.net/thing", nil)
 	responseWriter := httptest.NewRecorder()
-	request.Header.Add("Negotiate", "1.0")
+	request.Header.Add("Negotiate", "vlist, 1.0")
 	ctx := negotiator.NegotiationContext{Request: request, ResponseWriter: responseWriter}
 	v := _representation.NewBuilder().
 		WithLocation(*request.URL).
@@ -263,6 +271,147 @@ func (s TransparentTestSuite) TestTransparent_ChoiceResponse() {
 	s.Equal("choice", response.Header.Get("TCN"))
 }
 
+func (s TransparentTestSuite) TestTransparent_Decide_Choice() {
+	// arrange.
+	_json, english, ascii := "application/json", "en-US", "ascii"
+	request := httptest.NewRequest("GET", "http://freer.ddns.net/thing", nil)
+	request.Header.Add("Negotiate", "1.0")
+	v := _representation.NewBuilder().
+		WithLocation(*request.URL).
+		WithType(_json).
+		WithLanguage(english).
+		WithCharset(ascii).
+		WithSourceQuality(1.0).
+		Build(test.RepresentationBuilderFunc)
+	variants := []representation.Representation{v}
+	s.chooser.EXPECT().Choose(request, gomock.Any()).Return(v, nil)
+
+	// action.
+	d, err := s.sut.(negotiator.Decider).Decide(request, variants...)
+
+	// assert.
+	s.Require().NoError(err)
+	s.Equal(negotiator.OutcomeChoice, d.Outcome)
+	s.Equal(http.StatusOK, d.StatusCode)
+	s.Equal(v, d.Representation)
+	s.Equal(header.ResponseTypeChoice.String(), d.Header.Get("TCN"))
+	s.Empty(d.Header.Get("Alternates"))
+}
+
+func (s TransparentTestSuite) TestTransparent_Decide_List() {
+	// arrange.
+	_json, english, ascii := "application/json", "en-US", "ascii"
+	request := httptest.NewRequest("GET", "http://freer.ddns.net/thing", nil)
+	request.Header.Add("Negotiate", "trans")
+	v := _representation.NewBuilder().
+		WithLocation(*request.URL).
+		WithType(_json).
+		WithLanguage(english).
+		WithCharset(ascii).
+		WithSourceQuality(1.0).
+		Build(test.RepresentationBuilderFunc)
+	variants := []representation.Representation{v}
+
+	// action.
+	d, err := s.sut.(negotiator.Decider).Decide(request, variants...)
+
+	// assert.
+	s.Require().NoError(err)
+	s.Equal(negotiator.OutcomeList, d.Outcome)
+	s.Equal(http.StatusMultipleChoices, d.StatusCode)
+	s.Nil(d.Representation)
+	s.Equal(jsonList(variants...), d.List)
+	s.Equal(header.ResponseTypeList.String(), d.Header.Get("TCN"))
+	s.Equal("Negotiate", d.Header.Get("Vary"))
+}
+
+func (s TransparentTestSuite) TestTransparent_InvalidHeaders() {
+	tests := []struct {
+		name   string
+		policy negotiator.HeaderPolicy
+		err    error
+	}{
+		{"Reject", negotiator.RejectInvalidHeaders, header.ErrInvalidNegotiateDirective},
+		{"IgnoreElement", negotiator.IgnoreInvalidElements, nil},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			// arrange.
+			sut := transparent.New(
+				transparent.RVSA(s.chooser),
+				transparent.ListRepresentation(jsonList),
+				transparent.InvalidHeaders(tt.policy),
+			)
+			request := httptest.NewRequest("GET", "http://freer.ddns.net/thing", nil)
+			request.Header.Add("Negotiate", "vlist;x")
+			request.Header.Add("Negotiate", "trans")
+			v := _representation.NewBuilder().
+				WithLocation(*request.URL).
+				WithType("application/json").
+				WithSourceQuality(1.0).
+				Build(test.RepresentationBuilderFunc)
+
+			// action.
+			d, err := sut.Decide(request, v)
+
+			// assert.
+			if tt.err != nil {
+				s.Require().ErrorIs(err, negotiator.ErrInvalidHeader)
+				s.ErrorIs(err, tt.err)
+				return
+			}
+			s.Require().NoError(err)
+			s.Equal(negotiator.OutcomeList, d.Outcome)
+		})
+	}
+}
+
+func (s TransparentTestSuite) TestTransparent_NoRepresentations() {
+	tests := []struct {
+		name      string
+		negotiate []string
+	}{
+		{"MissingNegotiate", nil},
+		{"Trans", []string{"trans"}},
+		{"VList", []string{"vlist"}},
+		{"RVSA", []string{"1.0"}},
+		{"Wildcard", []string{"*"}},
+	}
+	for _, test := range tests {
+		s.Run(test.name, func() {
+			// arrange.
+			request := httptest.NewRequest("GET", "http://freer.ddns.net/thing", nil)
+			request.Header["Negotiate"] = test.negotiate
+			responseWriter := httptest.NewRecorder()
+			ctx := negotiator.NegotiationContext{Request: request, ResponseWriter: responseWriter}
+
+			// action.
+			err := s.sut.Negotiate(ctx)
+
+			// assert.
+			s.Require().NoError(err)
+			response := responseWriter.Result()
+			s.Equal(http.StatusNoContent, response.StatusCode)
+			s.Zero(responseWriter.Body.Len())
+		})
+	}
+}
+
+func (s TransparentTestSuite) TestTransparent_Decide_NoRepresentations() {
+	// arrange.
+	request := httptest.NewRequest("GET", "http://freer.ddns.net/thing", nil)
+	request.Header.Add("Negotiate", "trans, vlist")
+
+	// action.
+	d, err := s.sut.(negotiator.Decider).Decide(request)
+
+	// assert.
+	s.Require().NoError(err)
+	s.Equal(negotiator.OutcomeNoContent, d.Outcome)
+	s.Equal(http.StatusNoContent, d.StatusCode)
+	s.Nil(d.Body())
+}
+
 func (s TransparentTestSuite) TestTransparent_VariantListSizeExceeded() {
 	// arrange.
 	_json, english, ascii, gzip := "application/json", "en-US", "ascii", "gzip"
@@ -356,6 +505,624 @@ func (s TransparentTestSuite) TestTransparent_GuessSmallNegotiateHeader_ChoiceRe
 	s.Require().NoError(err)
 	response := responseWriter.Result()
 	s.Equal(header.ResponseTypeList.String(), response.Header.Get("TCN"))
+	s.Equal("Negotiate", response.Header.Get("Vary"))
+}
+
+func (s TransparentTestSuite) TestTransparent_HeaderCache() {
+	// arrange.
+	scope := tally.NewTestScope("", nil)
+	sut := transparent.New(
+		transparent.RVSA(transparent.RVSA1()),
+		transparent.HeaderCache(16),
+		transparent.Scope(scope),
+	)
+
+	for i := 0; i < 2; i++ {
+		request := httptest.NewRequest("GET", "http://freer.ddns.net/thing", nil)
+		request.Header.Add("Negotiate", "trans, 1.0")
+		request.Header.Add("Accept", "application/json")
+		v := _representation.NewBuilder().
+			WithLocation(*request.URL).
+			WithType("application/json").
+			WithSourceQuality(1.0).
+			Build(test.RepresentationBuilderFunc)
+
+		// action.
+		_, err := sut.Decide(request, v)
+
+		// assert.
+		s.Require().NoError(err)
+	}
+
+	// assert.
+	counters := scope.Snapshot().Counters()
+	s.Equal(int64(6), counters["negotiate.header_cache.miss+negotiator=transparent"].Value())
+	s.Equal(int64(6), counters["negotiate.header_cache.hit+negotiator=transparent"].Value())
+}
+
+func (s TransparentTestSuite) TestTransparent_NormalResponse() {
+	// arrange.
+	_json, english, ascii := "application/json", "en-US", "ascii"
+	v := _representation.NewBuilder().
+		WithType(_json).
+		WithLanguage(english).
+		WithCharset(ascii).
+		WithSourceQuality(1.0).
+		Build(test.RepresentationBuilderFunc)
+
+	tests := []struct {
+		name      string
+		negotiate []string
+		chosen    representation.Representation
+		outcome   negotiator.Outcome
+		status    int
+	}{
+		{"Chosen", nil, v, negotiator.OutcomeAcceptable, http.StatusOK},
+		{"NoneChosen", nil, nil, negotiator.OutcomeMultipleChoices, http.StatusMultipleChoices},
+		{"Extension", []string{"x-thing"}, v, negotiator.OutcomeAcceptable, http.StatusOK},
+	}
+	for _, test := range tests {
+		s.Run(test.name, func() {
+			// arrange.
+			request := httptest.NewRequest("GET", "http://freer.ddns.net/thing", nil)
+			request.Header["Negotiate"] = test.negotiate
+			s.chooser.EXPECT().Choose(gomock.Any(), gomock.Any()).Return(test.chosen, nil)
+
+			// action.
+			d, err := s.sut.(negotiator.Decider).Decide(request, v)
+
+			// assert.
+			s.Require().NoError(err)
+			s.Equal(test.outcome, d.Outcome)
+			s.Equal(test.status, d.StatusCode)
+			s.Equal(test.chosen, d.Representation)
+			s.Empty(d.Header.Get("TCN"))
+			s.Empty(d.Header.Get("Alternates"))
+			s.Contains(d.Header.Get("Vary"), "Negotiate")
+		})
+	}
+}
+
+func (s TransparentTestSuite) TestTransparent_NormalResponse_Browser() {
+	// arrange.
+	html := _representation.NewBuilder().
+		WithType("text/html").
+		WithLanguage("en-US").
+		WithSourceQuality(1.0).
+		Build(test.RepresentationBuilderFunc)
+	_json := _representation.NewBuilder().
+		WithType("application/json").
+		WithLanguage("en-US").
+		WithSourceQuality(1.0).
+		Build(test.RepresentationBuilderFunc)
+	sut := transparent.New()
+
+	tests := []struct {
+		name    string
+		accept  string
+		chosen  representation.Representation
+		outcome negotiator.Outcome
+		status  int
+	}{
+		{
+			"Acceptable",
+			"text/html,application/xhtml+xml,application/xml;q=0.9,image/avif,image/webp,*/*;q=0.8",
+			html,
+			negotiator.OutcomeAcceptable,
+			http.StatusOK,
+		},
+		{
+			"NoneAcceptable",
+			"image/avif,image/webp",
+			nil,
+			negotiator.OutcomeMultipleChoices,
+			http.StatusMultipleChoices,
+		},
+	}
+	for _, test := range tests {
+		s.Run(test.name, func() {
+			// arrange.
+			request := httptest.NewRequest("GET", "http://freer.ddns.net/thing", nil)
+			request.Header.Set("Accept", test.accept)
+			request.Header.Set("Accept-Language", "en-US,en;q=0.9")
+			request.Header.Set("Accept-Encoding", "gzip, deflate, br, zstd")
+
+			// action.
+			d, err := sut.Decide(request, _json, html)
+
+			// assert.
+			s.Require().NoError(err)
+			s.Equal(test.outcome, d.Outcome)
+			s.Equal(test.status, d.StatusCode)
+			s.Equal(test.chosen, d.Representation)
+		})
+	}
+}
+
+func (s TransparentTestSuite) TestTransparent_AlternatesOnChoiceResponse() {
+	// arrange.
+	loc := httptest.NewRequest("GET", "http://freer.ddns.net/thing", nil).URL
+	v := _representation.NewBuilder().
+		WithLocation(*loc).
+		WithType("application/json").
+		WithSourceQuality(1.0).
+		Build(test.RepresentationBuilderFunc)
+
+	tests := []struct {
+		negotiate  string
+		alternates bool
+	}{
+		{"1.0", false},
+		{"vlist, 1.0", true},
+		{"trans, 1.0", true},
+		{"guess-small", false},
+		{"*", false},
+	}
+	for _, test := range tests {
+		s.Run(test.negotiate, func() {
+			// arrange.
+			request := httptest.NewRequest("GET", "http://freer.ddns.net/thing", nil)
+			request.Header.Add("Negotiate", test.negotiate)
+			s.chooser.EXPECT().Choose(gomock.Any(), gomock.Any()).Return(v, nil)
+
+			// action.
+			d, err := s.sut.(negotiator.Decider).Decide(request, v)
+
+			// assert.
+			s.Require().NoError(err)
+			s.Equal(negotiator.OutcomeChoice, d.Outcome)
+			s.Equal(test.alternates, len(d.Header.Get("Alternates")) > 0)
+		})
+	}
+}
+
+func (s TransparentTestSuite) TestTransparent_EntityTags() {
+	// arrange.
+	request := httptest.NewRequest("GET", "http://freer.ddns.net/thing", nil)
+	json := _representation.NewBuilder().
+		WithLocation(*request.URL).
+		WithType("application/json").
+		WithSourceQuality(1.0).
+		Build(test.RepresentationBuilderFunc)
+	xml := _representation.NewBuilder().
+		WithLocation(*request.URL).
+		WithType("application/xml").
+		WithSourceQuality(0.9).
+		Build(test.RepresentationBuilderFunc)
+	s.chooser.EXPECT().Choose(gomock.Any(), gomock.Any()).Return(json, nil)
+	list := request.Clone(request.Context())
+	list.Header.Set("Negotiate", "trans")
+	choice := request.Clone(request.Context())
+	choice.Header.Set("Negotiate", "vlist, 1.0")
+
+	// action.
+	l, lerr := s.sut.(negotiator.Decider).Decide(list, json, xml)
+	c, cerr := s.sut.(negotiator.Decider).Decide(choice, json, xml)
+
+	// assert.
+	s.Require().NoError(lerr)
+	s.Require().NoError(cerr)
+	alternates, err := header.ParseAlternates(c.Header.Values("Alternates"))
+	s.Require().NoError(err)
+	validator := alternates.VariantListValidator()
+	s.Equal(`"`+validator+`"`, l.Header.Get("ETag"))
+	s.Empty(c.Header.Get("ETag"))
+}
+
+func (s TransparentTestSuite) TestTransparent_EntityTags_Variant() {
+	// arrange.
+	request := httptest.NewRequest("GET", "http://freer.ddns.net/thing", nil)
+	request.Header.Set("Negotiate", "vlist, 1.0")
+	variant := func(a, tag string) representation.Representation {
+		r := test.Representation{A: a}
+		r.SetContentLocation(*request.URL)
+		r.SetContentType("application/json")
+		r.SetSourceQuality(1.0)
+		return taggedRepresentation{Representation: r, tag: tag}
+	}
+	etag := func(rep representation.Representation) string {
+		s.chooser.EXPECT().Choose(gomock.Any(), gomock.Any()).Return(rep, nil)
+		d, err := s.sut.(negotiator.Decider).Decide(request, rep)
+		s.Require().NoError(err)
+		alternates, err := header.ParseAlternates(d.Header.Values("Alternates"))
+		s.Require().NoError(err)
+		return strings.Replace(d.Header.Get("ETag"), alternates.VariantListValidator(), "vlv", 1)
+	}
+
+	// action.
+	one, two := etag(variant("one", "")), etag(variant("two", ""))
+	strong := etag(variant("one", `"rev-7"`))
+	weak := etag(variant("one", `W/"rev-7"`))
+
+	// assert.
+	s.Empty(one)
+	s.Empty(two)
+	s.Equal(`"rev-7;vlv"`, strong)
+	s.Empty(weak)
+}
+
+func (s TransparentTestSuite) TestTransparent_ChoiceResponse_Unserialized() {
+	// arrange.
+	request := httptest.NewRequest("GET", "http://freer.ddns.net/thing", nil)
+	request.Header.Set("Negotiate", "1.0")
+	serialized := 0
+	variant := func(a string) representation.Representation {
+		r := test.Representation{A: a}
+		r.SetContentLocation(*request.URL)
+		r.SetContentType("application/json")
+		r.SetSourceQuality(1.0)
+		return serializedRepresentation{Representation: r, serialized: &serialized}
+	}
+	one, two := variant("one"), variant("two")
+	s.chooser.EXPECT().Choose(gomock.Any(), gomock.Any()).Return(one, nil)
+
+	// action.
+	d, err := s.sut.(negotiator.Decider).Decide(request, one, two)
+
+	// assert.
+	s.Require().NoError(err)
+	s.Equal(negotiator.OutcomeChoice, d.Outcome)
+	s.Empty(d.Header.Get("Alternates"))
+	s.Empty(d.Header.Get("ETag"))
+	s.Zero(serialized)
+}
+
+func (s TransparentTestSuite) TestTransparent_AdhocResponse() {
+	// arrange.
+	loc := httptest.NewRequest("GET", "http://freer.ddns.net/thing", nil).URL
+	v := _representation.NewBuilder().
+		WithLocation(*loc).
+		WithType("application/json").
+		WithSourceQuality(1.0).
+		Build(test.RepresentationBuilderFunc)
+	sut := transparent.New(
+		transparent.RVSA(s.chooser),
+		transparent.ServerChooser(s.chooser),
+		transparent.AdhocUserAgents("BuggyBrowser"),
+	)
+
+	tests := []struct {
+		name      string
+		userAgent string
+		chosen    representation.Representation
+		outcome   negotiator.Outcome
+		status    int
+		tcn       string
+	}{
+		{"Chosen", "Mozilla/5.0 buggybrowser/1.2", v, negotiator.OutcomeAdhoc, http.StatusOK, "adhoc"},
+		{"NoneChosen", "BuggyBrowser/1.2", nil, negotiator.OutcomeAdhoc, http.StatusMultipleChoices, "adhoc"},
+		{"OtherUserAgent", "Mozilla/5.0", v, negotiator.OutcomeChoice, http.StatusOK, "choice"},
+	}
+	for _, test := range tests {
+		s.Run(test.name, func() {
+			// arrange.
+			request := httptest.NewRequest("GET", "http://freer.ddns.net/thing", nil)
+			request.Header.Set("User-Agent", test.userAgent)
+			request.Header.Set("Negotiate", "1.0")
+			s.chooser.EXPECT().Choose(gomock.Any(), gomock.Any()).Return(test.chosen, nil)
+
+			// action.
+			d, err := sut.Decide(request, v)
+
+			// assert.
+			s.Require().NoError(err)
+			s.Equal(test.outcome, d.Outcome)
+			s.Equal(test.status, d.StatusCode)
+			s.Equal(test.chosen, d.Representation)
+			s.Equal(test.tcn, d.Header.Get("TCN"))
+		})
+	}
+}
+
+func (s TransparentTestSuite) TestTransparent_Overrides() {
+	// arrange.
+	loc := httptest.NewRequest("GET", "http://freer.ddns.net/thing", nil).URL
+	v := _representation.NewBuilder().
+		WithLocation(*loc).
+		WithType("application/json").
+		WithSourceQuality(1.0).
+		Build(test.RepresentationBuilderFunc)
+	sut := transparent.New(
+		transparent.RVSA(s.chooser),
+		transparent.ListOverride(header.OverrideDirectiveKeep),
+		transparent.ChoiceOverride(header.OverrideDirectiveReChoose),
+	)
+
+	tests := []struct {
+		negotiate  string
+		tcn        string
+		alternates bool
+	}{
+		{"trans", "list,keep", true},
+		{"1.0", "choice,re-choose", true},
+	}
+	for _, test := range tests {
+		s.Run(test.negotiate, func() {
+			// arrange.
+			request := httptest.NewRequest("GET", "http://freer.ddns.net/thing", nil)
+			request.Header.Set("Negotiate", test.negotiate)
+			s.chooser.EXPECT().Choose(gomock.Any(), gomock.Any()).Return(v, nil).AnyTimes()
+
+			// action.
+			d, err := sut.Decide(request, v)
+
+			// assert.
+			s.Require().NoError(err)
+			s.Equal(test.tcn, d.Header.Get("TCN"))
+			s.Equal(test.alternates, len(d.Header.Get("Alternates")) > 0)
+		})
+	}
+}
+
+func (s TransparentTestSuite) TestTransparent_ResponsePolicy() {
+	// arrange.
+	loc := httptest.NewRequest("GET", "http://freer.ddns.net/thing", nil).URL
+	v := _representation.NewBuilder().
+		WithLocation(*loc).
+		WithType("application/json").
+		WithSourceQuality(1.0).
+		Build(test.RepresentationBuilderFunc)
+	s.chooser.EXPECT().Choose(gomock.Any(), gomock.Any()).Return(v, nil).Times(2)
+	sut := transparent.New(
+		transparent.RVSA(s.chooser),
+		transparent.ServerChooser(s.chooser),
+		transparent.AdhocUserAgents("Ignored"),
+		transparent.ResponsePolicy(transparent.UserAgentPolicy(func(userAgent string) transparent.PolicyDecision {
+			if userAgent == "Legacy/1.0" {
+				return transparent.PolicyDecision{Adhoc: true}
+			}
+			return transparent.PolicyDecision{ChoiceOverride: header.OverrideDirectiveKeep}
+		})),
+	)
+
+	tests := []struct {
+		userAgent string
+		tcn       string
+	}{
+		{"Legacy/1.0", "adhoc"},
+		{"Ignored/1.0", "choice,keep"},
+	}
+	for _, test := range tests {
+		s.Run(test.userAgent, func() {
+			// arrange.
+			request := httptest.NewRequest("GET", "http://freer.ddns.net/thing", nil)
+			request.Header.Set("User-Agent", test.userAgent)
+			request.Header.Set("Negotiate", "1.0")
+
+			// action.
+			d, err := sut.Decide(request, v)
+
+			// assert.
+			s.Require().NoError(err)
+			s.Equal(test.tcn, d.Header.Get("TCN"))
+		})
+	}
+}
+
+func (s TransparentTestSuite) TestTransparent_VariantAlsoNegotiates() {
+	// arrange.
+	scope := tally.NewTestScope("", nil)
+	registry := transparent.NewRegistry()
+	registry.Register("/thing.json")
+	sut := transparent.New(
+		transparent.RVSA(s.chooser),
+		transparent.NegotiableResources(registry),
+		transparent.Scope(scope),
+	)
+	request := httptest.NewRequest("GET", "http://freer.ddns.net/thing", nil)
+	request.Header.Set("Negotiate", "trans")
+	json := _representation.NewBuilder().
+		WithLocation(url.URL{Path: "/thing.json"}).
+		WithType("application/json").
+		WithSourceQuality(1.0).
+		Build(test.RepresentationBuilderFunc)
+	xml := _representation.NewBuilder().
+		WithLocation(url.URL{Path: "/thing.xml"}).
+		WithType("application/xml").
+		WithSourceQuality(1.0).
+		Build(test.RepresentationBuilderFunc)
+
+	// action.
+	d, err := sut.Decide(request, json, xml)
+
+	// assert.
+	s.Require().NoError(err)
+	s.Equal(negotiator.OutcomeVariantAlsoNegotiates, d.Outcome)
+	s.Equal(http.StatusVariantAlsoNegotiates, d.StatusCode)
+	s.Equal(representation.ProblemJSON, d.Header.Get("Content-Type"))
+	s.Empty(d.Header.Get("TCN"))
+	counters := scope.Snapshot().Counters()
+	s.Equal(int64(1), counters["negotiate.variant_also_negotiates+negotiator=transparent"].Value())
+}
+
+func (s TransparentTestSuite) TestTransparent_VariantAlsoNegotiates_VariantRequest() {
+	// arrange.
+	problem := representation.VariantAlsoNegotiatesProblem(representation.ProblemXML)
+	registry := transparent.NewRegistry()
+	sut := transparent.New(
+		transparent.RVSA(s.chooser),
+		transparent.NegotiableResources(registry),
+		transparent.VariantAlsoNegotiatesRepresentation(problem),
+	)
+	json := _representation.NewBuilder().
+		WithLocation(url.URL{Path: "/thing.json"}).
+		WithType("application/json").
+		WithSourceQuality(1.0).
+		Build(test.RepresentationBuilderFunc)
+	registry.RegisterVariants(json)
+
+	tests := []struct {
+		name      string
+		negotiate []string
+		outcome   negotiator.Outcome
+	}{
+		{"Negotiate", []string{"trans"}, negotiator.OutcomeVariantAlsoNegotiates},
+		{"NoNegotiate", nil, negotiator.OutcomeAcceptable},
+	}
+	for _, test := range tests {
+		s.Run(test.name, func() {
+			// arrange.
+			request := httptest.NewRequest("GET", "http://freer.ddns.net/thing.json", nil)
+			request.Header["Negotiate"] = test.negotiate
+			s.chooser.EXPECT().Choose(gomock.Any(), gomock.Any()).Return(json, nil).AnyTimes()
+
+			// action.
+			d, err := sut.Decide(request, json)
+
+			// assert.
+			s.Require().NoError(err)
+			s.Equal(test.outcome, d.Outcome)
+		})
+	}
+}
+
+func (s TransparentTestSuite) TestTransparent_VariantAlsoNegotiates_Unregistered() {
+	// arrange.
+	registry := transparent.NewRegistry()
+	registry.Register("/")
+	sut := transparent.New(
+		transparent.RVSA(s.chooser),
+		transparent.NegotiableResources(registry),
+	)
+	json := _representation.NewBuilder().
+		WithLocation(url.URL{Path: "/thing.json"}).
+		WithType("application/json").
+		WithSourceQuality(1.0).
+		Build(test.RepresentationBuilderFunc)
+	unlocated := _representation.NewBuilder().
+		WithType("application/xml").
+		WithSourceQuality(1.0).
+		Build(test.RepresentationBuilderFunc)
+	s.chooser.EXPECT().Choose(gomock.Any(), gomock.Any()).Return(json, nil).AnyTimes()
+	resource := httptest.NewRequest("GET", "http://freer.ddns.net/thing", nil)
+	resource.Header.Set("Negotiate", "trans")
+	variant := httptest.NewRequest("GET", "http://freer.ddns.net/thing.json", nil)
+	variant.Header.Set("Negotiate", "trans")
+
+	// action.
+	r, rerr := sut.Decide(resource, json, unlocated)
+	v, verr := sut.Decide(variant, json)
+
+	// assert.
+	s.Require().NoError(rerr)
+	s.Require().NoError(verr)
+	s.NotEqual(negotiator.OutcomeVariantAlsoNegotiates, r.Outcome)
+	s.NotEqual(negotiator.OutcomeVariantAlsoNegotiates, v.Outcome)
+	s.False(registry.IsVariant(url.URL{Path: "/thing.json"}))
+}
+
+func (s TransparentTestSuite) TestTransparent_RVSAVersions() {
+	// arrange.
+	json := _representation.NewBuilder().
+		WithLocation(url.URL{Scheme: "http", Host: "freer.ddns.net", Path: "/thing.json"}).
+		WithType("application/json").
+		WithSourceQuality(1.0).
+		Build(test.RepresentationBuilderFunc)
+	xml := _representation.NewBuilder().
+		WithLocation(url.URL{Scheme: "http", Host: "freer.ddns.net", Path: "/thing.xml"}).
+		WithType("application/xml").
+		WithSourceQuality(1.0).
+		Build(test.RepresentationBuilderFunc)
+	tests := []struct {
+		name      string
+		negotiate string
+		rvsa      string
+		outcome   negotiator.Outcome
+	}{
+		{"Advertised", "1.2", "1.2", negotiator.OutcomeChoice},
+		{"HigherMinor", "1.0", "1.2", negotiator.OutcomeChoice},
+		{"Highest", "1.0, 2.0", "2.0", negotiator.OutcomeChoice},
+		{"Wildcard", "*", "2.0", negotiator.OutcomeChoice},
+		{"GuessSmall", "guess-small", "2.0", negotiator.OutcomeChoice},
+		{"Unsupported", "1.3, 3.0", "", negotiator.OutcomeList},
+	}
+	for _, test := range tests {
+		s.Run(test.name, func() {
+			// arrange.
+			scope := tally.NewTestScope("", nil)
+			choosers := map[string]*mock.Chooser{
+				"1.0": mock.NewChooser(s.mc),
+				"1.2": mock.NewChooser(s.mc),
+				"2.0": mock.NewChooser(s.mc),
+			}
+			if c, ok := choosers[test.rvsa]; ok {
+				c.EXPECT().Choose(gomock.Any(), gomock.Any()).Return(json, nil)
+			}
+			sut := transparent.New(
+				transparent.RVSA(choosers["1.0"]),
+				transparent.RVSAVersion("1.2", choosers["1.2"]),
+				transparent.RVSAVersion("2.0", choosers["2.0"]),
+				transparent.ListRepresentation(jsonList),
+				transparent.Scope(scope),
+			)
+			request := httptest.NewRequest("GET", "http://freer.ddns.net/thing", nil)
+			request.Header.Set("Negotiate", test.negotiate)
+
+			// action.
+			d, err := sut.Decide(request, json, xml)
+
+			// assert.
+			s.Require().NoError(err)
+			s.Equal(test.outcome, d.Outcome)
+			counters := scope.Snapshot().Counters()
+			if len(test.rvsa) == 0 {
+				s.Nil(counters["negotiate.rvsa+negotiator=transparent,rvsa="+test.rvsa])
+				return
+			}
+			s.Equal(int64(1), counters["negotiate.rvsa+negotiator=transparent,rvsa="+test.rvsa].Value())
+		})
+	}
+}
+
+func (s TransparentTestSuite) TestTransparent_RVSAVersion_Invalid() {
+	// arrange.
+	experimental := mock.NewChooser(s.mc)
+	sut := transparent.New(
+		transparent.RVSA(s.chooser),
+		transparent.RVSAVersion("experimental", experimental),
+		transparent.ListRepresentation(jsonList),
+	)
+	json := _representation.NewBuilder().
+		WithLocation(url.URL{Scheme: "http", Host: "freer.ddns.net", Path: "/thing.json"}).
+		WithType("application/json").
+		WithSourceQuality(1.0).
+		Build(test.RepresentationBuilderFunc)
+	request := httptest.NewRequest("GET", "http://freer.ddns.net/thing", nil)
+	request.Header.Set("Negotiate", "*")
+	s.chooser.EXPECT().Choose(request, gomock.Any()).Return(json, nil)
+
+	// action.
+	d, err := sut.Decide(request, json)
+
+	// assert.
+	s.Require().NoError(err)
+	s.Equal(negotiator.OutcomeChoice, d.Outcome)
+}
+
+func (s TransparentTestSuite) TestTransparent_TCNExtensions() {
+	// arrange.
+	sut := transparent.New(
+		transparent.RVSA(transparent.RVSA1()),
+		transparent.TCNExtensions("x-thing", `x-other="a, b"`),
+	)
+	request := httptest.NewRequest("GET", "http://freer.ddns.net/thing", nil)
+	request.Header.Add("Negotiate", "trans")
+	v := _representation.NewBuilder().
+		WithLocation(*request.URL).
+		WithType("application/json").
+		WithSourceQuality(1.0).
+		Build(test.RepresentationBuilderFunc)
+
+	// action.
+	d, err := sut.Decide(request, v)
+
+	// assert.
+	s.Require().NoError(err)
+	s.Equal(`list,x-thing,x-other="a, b"`, d.Header.Get("TCN"))
+	tcn, err := header.NewTCN(d.Header.Values("TCN"))
+	s.Require().NoError(err)
+	s.Equal(header.ResponseTypeList, tcn.ResponseType())
+	s.Equal([]header.TCNValue{"x-thing", `x-other="a, b"`}, tcn.Extensions())
 }
 
 func (s *TransparentTestSuite) TearDownTest() {
@@ -364,3 +1131,28 @@ func (s *TransparentTestSuite) TearDownTest() {
 	s.sut = nil
 	s.chooser = nil
 }
+
+// taggedRepresentation represents a representation that reports its entity
+// tag.
+type taggedRepresentation struct {
+	test.Representation
+
+	tag string
+}
+
+func (r taggedRepresentation) EntityTag() (string, bool) {
+	return r.tag, len(r.tag) > 0
+}
+
+// serializedRepresentation represents a representation that counts the
+// number of times it is serialized.
+type serializedRepresentation struct {
+	test.Representation
+
+	serialized *int
+}
+
+func (r serializedRepresentation) Bytes() ([]byte, error) {
+	*r.serialized++
+	return r.Representation.Bytes()
+}